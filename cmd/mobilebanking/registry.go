@@ -2,15 +2,29 @@ package main
 
 import (
 	"log"
-	"net/http"
 
 	"github.com/go-chi/chi"
 	"github.com/tunaiku/mobilebanking/internal/app/authentication"
+	"github.com/tunaiku/mobilebanking/internal/app/bank"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary"
+	"github.com/tunaiku/mobilebanking/internal/app/clearing"
+	"github.com/tunaiku/mobilebanking/internal/app/limit"
 	"github.com/tunaiku/mobilebanking/internal/app/savings"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction"
 	"github.com/tunaiku/mobilebanking/internal/app/user"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/health"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+	"github.com/tunaiku/mobilebanking/internal/pkg/logger"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
 	"github.com/tunaiku/mobilebanking/internal/pkg/pg"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+	"github.com/tunaiku/mobilebanking/internal/pkg/tracing"
 	"go.uber.org/dig"
+	"go.uber.org/zap"
 )
 
 var (
@@ -19,25 +33,53 @@ var (
 
 func init() {
 	log.Println("register ...")
+	config.Register(container)
+	jwt.Register(container)
+	logger.Register(container)
+	lifecycle.Register(container)
+	health.Register(container)
+	metrics.Register(container)
+	tracing.Register(container)
+	ratelimit.Register(container)
+	openapi.Register(container)
 	transaction.Register(container)
+	standingorder.Register(container)
+	beneficiary.Register(container)
+	limit.Register(container)
+	bank.Register(container)
+	clearing.Register(container)
 	pg.Register(container)
 	authentication.Register(container)
 	savings.Register(container)
 	user.Register(container)
-	container.Provide(func() chi.Router {
-		return chi.NewRouter()
+	container.Provide(func(appMetrics *metrics.Metrics, appLogger *zap.Logger) chi.Router {
+		router := chi.NewRouter()
+		router.Use(logger.RequestID(appLogger), tracing.Middleware, logger.AccessLog, appMetrics.Middleware)
+		return router
 	})
 }
 
 func invoke() {
+	logger.Invoke(container)
+	tracing.Invoke(container)
 	transaction.Invoke(container)
+	standingorder.Invoke(container)
+	beneficiary.Invoke(container)
+	limit.Invoke(container)
+	bank.Invoke(container)
+	clearing.Invoke(container)
 	authentication.Invoke(container)
 	savings.Invoke(container)
 	user.Invoke(container)
+	ratelimit.Invoke(container)
 	pg.Invoke(container)
-	err := container.Invoke(func(router chi.Router) error {
+	health.Invoke(container)
+	metrics.Invoke(container)
+	openapi.Invoke(container)
+	tracing.OnStop(container)
+	err := container.Invoke(func(serverLifecycle *lifecycle.Lifecycle) error {
 		log.Println("running server ...")
-		return http.ListenAndServe(":8080", router)
+		return serverLifecycle.Run()
 	})
 	if err != nil {
 		log.Fatal(err)
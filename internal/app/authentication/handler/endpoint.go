@@ -1,26 +1,33 @@
 package handler
 
 import (
-	"log"
 	"net/http"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/render"
 	"github.com/micro/go-micro/v3/errors"
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/logger"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+	"go.uber.org/zap"
 )
 
 type AuthenticationEndpoint struct {
 	authenticationService domain.AuthenticationService
+	limiter               *ratelimit.Limiter
 }
 
-func NewAuthenticationEndpoint(authenticationService domain.AuthenticationService) *AuthenticationEndpoint {
-	return &AuthenticationEndpoint{authenticationService: authenticationService}
+func NewAuthenticationEndpoint(authenticationService domain.AuthenticationService,
+	limiter *ratelimit.Limiter) *AuthenticationEndpoint {
+	return &AuthenticationEndpoint{authenticationService: authenticationService, limiter: limiter}
 }
 
 func (endpoint AuthenticationEndpoint) BindRoutes(r chi.Router) {
 	r.Group(func(r chi.Router) {
-		r.Post("/auth/authenticate", endpoint.HandleAuthenticationFlow)
+		r.Use(endpoint.limiter.Limit(ratelimit.GroupAuth))
+		r.With(openapi.ValidateRequest(AuthenticateOperation)).
+			Post("/auth/authenticate", endpoint.HandleAuthenticationFlow)
 	})
 }
 
@@ -34,11 +41,12 @@ func (endpoint AuthenticationEndpoint) HandleAuthenticationFlow(w http.ResponseW
 	if err != nil {
 		switch v := err.(type) {
 		case *errors.Error:
-			log.Print(v.Error())
+			logger.FromContext(r.Context()).Warn("authentication failed", zap.String("username", request.Username),
+				zap.String("reason", v.Detail))
 			render.Render(w, r, &AuthenticationFailedResponse{Message: v.Detail, HTTPStatus: int(v.Code)})
 			return
 		default:
-			log.Print(v.Error())
+			logger.FromContext(r.Context()).Error("authentication failed", zap.Error(v))
 			render.Render(w, r, &AuthenticationFailedResponse{Message: v.Error(), HTTPStatus: http.StatusInternalServerError})
 			return
 		}
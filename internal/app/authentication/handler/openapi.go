@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
+)
+
+var AuthenticateOperation = openapi.Operation{
+	ID:      "authenticate",
+	Method:  http.MethodPost,
+	Path:    "/auth/authenticate",
+	Summary: "Exchange the username and password for the access token sent as bearer token to the other routes",
+	Tag:     "authentication",
+	Request: AuthenticationRequest{},
+	Responses: map[int]openapi.Response{
+		http.StatusOK: {Description: "The user is authenticated", Body: AuthenticationResponse{}},
+		http.StatusBadRequest: {Description: "The request body is invalid or the credential does not match",
+			Body: AuthenticationFailedResponse{}},
+		http.StatusTooManyRequests: {Description: "The rate limit is exceeded, retry after the Retry-After header",
+			Body: openapi.ErrorResponse{}},
+		http.StatusInternalServerError: {Description: "The user cannot be authenticated",
+			Body: AuthenticationFailedResponse{}},
+	},
+}
+
+// Operations documents the routes bound by BindRoutes
+func Operations() []openapi.Operation {
+	return []openapi.Operation{AuthenticateOperation}
+}
@@ -0,0 +1,25 @@
+package handler_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/app/authentication/handler"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+)
+
+func TestOperations_Should_DocumentEveryBoundRoute(t *testing.T) {
+	router := chi.NewRouter()
+	handler.NewAuthenticationEndpoint(nil, ratelimit.NewLimiter(nil, config.RateLimitConfig{})).BindRoutes(router)
+
+	bound, err := openapi.BoundRoutes(router)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if documented := openapi.Routes(handler.Operations()); !reflect.DeepEqual(bound, documented) {
+		t.Fatalf("the specification drifted from the routes, bound %v but documented %v", bound, documented)
+	}
+}
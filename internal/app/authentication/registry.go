@@ -7,16 +7,22 @@ import (
 	"github.com/tunaiku/mobilebanking/internal/app/authentication/handler"
 	"github.com/tunaiku/mobilebanking/internal/app/authentication/service"
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
 	"go.uber.org/dig"
 )
 
 func Register(container *dig.Container) {
-	container.Provide(func(userRepository domain.UserRepository) domain.AuthenticationService {
-		return service.NewAuthenticationServiceImpl(userRepository)
+	container.Provide(func(userRepository domain.UserRepository, metrics *metrics.Metrics,
+		authority *jwt.Authority) domain.AuthenticationService {
+		return service.NewAuthenticationServiceImpl(userRepository, metrics, authority)
 	})
 
-	container.Provide(func(authenticationService domain.AuthenticationService) *handler.AuthenticationEndpoint {
-		return handler.NewAuthenticationEndpoint(authenticationService)
+	container.Provide(func(authenticationService domain.AuthenticationService,
+		limiter *ratelimit.Limiter) *handler.AuthenticationEndpoint {
+		return handler.NewAuthenticationEndpoint(authenticationService, limiter)
 	})
 
 	container.Provide(func(userRepository domain.UserRepository) domain.UserSessionHelper {
@@ -25,9 +31,11 @@ func Register(container *dig.Container) {
 }
 
 func Invoke(container *dig.Container) {
-	err := container.Invoke(func(router chi.Router, endpoint *handler.AuthenticationEndpoint) {
+	err := container.Invoke(func(router chi.Router, endpoint *handler.AuthenticationEndpoint,
+		document *openapi.Document) {
 		log.Println("invoke authentication startup ...")
 		endpoint.BindRoutes(router)
+		document.Add(handler.Operations()...)
 	})
 	if err != nil {
 		log.Fatalln(err)
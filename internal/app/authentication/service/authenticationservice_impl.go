@@ -5,15 +5,19 @@ import (
 	"github.com/micro/go-micro/v3/errors"
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
 	authJwt "github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthenticationServiceImpl struct {
 	repository domain.UserRepository
+	metrics    *metrics.Metrics
+	authority  *authJwt.Authority
 }
 
-func NewAuthenticationServiceImpl(repository domain.UserRepository) *AuthenticationServiceImpl {
-	return &AuthenticationServiceImpl{repository: repository}
+func NewAuthenticationServiceImpl(repository domain.UserRepository, metrics *metrics.Metrics,
+	authority *authJwt.Authority) *AuthenticationServiceImpl {
+	return &AuthenticationServiceImpl{repository: repository, metrics: metrics, authority: authority}
 }
 
 func (srv *AuthenticationServiceImpl) Authenticate(username string, password string) (domain.AuthenticationResult, error) {
@@ -21,6 +25,7 @@ func (srv *AuthenticationServiceImpl) Authenticate(username string, password str
 	if err != nil {
 		switch err {
 		case domain.ErrUserNotFound:
+			srv.metrics.LoginFailed()
 			return domain.AuthenticationResult{}, errors.BadRequest("com.tunaiku.service.mbanking", "invalid credential")
 		default:
 			return domain.AuthenticationResult{}, errors.InternalServerError("com.tunaiku.service.mbanking", err.Error())
@@ -31,20 +36,21 @@ func (srv *AuthenticationServiceImpl) Authenticate(username string, password str
 	if err != nil {
 		switch err {
 		case bcrypt.ErrMismatchedHashAndPassword:
+			srv.metrics.LoginFailed()
 			return domain.AuthenticationResult{}, domain.ErrCredentialNotMatch
 		default:
 			return domain.AuthenticationResult{}, errors.InternalServerError("com.tunaiku.service.mbanking", err.Error())
 		}
 	}
-	accessToken, err := mapToJwt(user)
+	accessToken, err := srv.mapToJwt(user)
 	if err != nil {
 		return domain.AuthenticationResult{}, errors.InternalServerError("com.tunaiku.service.mbanking", err.Error())
 	}
 	return domain.AuthenticationResult{AccessToken: accessToken}, nil
 }
 
-func mapToJwt(user *domain.User) (token string, err error) {
-	token, err = authJwt.CreateTokenString(func() jwt.Claims {
+func (srv *AuthenticationServiceImpl) mapToJwt(user *domain.User) (token string, err error) {
+	token, err = srv.authority.CreateTokenString(func() jwt.Claims {
 		return jwt.StandardClaims{Subject: user.ID}
 	})
 	return
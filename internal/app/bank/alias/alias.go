@@ -0,0 +1,17 @@
+package alias
+
+import (
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+const (
+	InternalBankCode string = "TUNAIKU"
+)
+
+var Banks = []domain.Bank{
+	{Code: InternalBankCode, Name: "Tunaiku", Internal: true},
+	{Code: "BCA", Name: "Bank Central Asia"},
+	{Code: "BNI", Name: "Bank Negara Indonesia"},
+	{Code: "BRI", Name: "Bank Rakyat Indonesia"},
+	{Code: "MANDIRI", Name: "Bank Mandiri"},
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+)
+
+type BankEndpoint struct {
+	bankDirectory domain.BankDirectory
+	authority     *jwt.Authority
+}
+
+func NewBankEndpoint(bankDirectory domain.BankDirectory, authority *jwt.Authority) *BankEndpoint {
+	return &BankEndpoint{bankDirectory: bankDirectory, authority: authority}
+}
+
+func (endpoint *BankEndpoint) BindRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r = endpoint.authority.WrapChiRouterWithAuthorization(r)
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				next.ServeHTTP(w, r)
+			})
+		})
+		r.Get("/banks", endpoint.HandleListBank)
+	})
+}
+
+func (endpoint *BankEndpoint) HandleListBank(w http.ResponseWriter, r *http.Request) {
+	banks, err := endpoint.bankDirectory.FindBanks()
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		render.JSON(w, r, &BankHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	response := &ListBankSuccess{Banks: []BankPayload{}}
+	for _, bank := range banks {
+		response.Banks = append(response.Banks, BankPayload{Code: bank.Code, Name: bank.Name, Internal: bank.Internal})
+	}
+	render.JSON(w, r, response)
+}
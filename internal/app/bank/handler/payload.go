@@ -0,0 +1,15 @@
+package handler
+
+type BankHandlerFailed struct {
+	Message string `json:"message"`
+}
+
+type BankPayload struct {
+	Code     string `json:"code"`
+	Name     string `json:"name"`
+	Internal bool   `json:"internal"`
+}
+
+type ListBankSuccess struct {
+	Banks []BankPayload `json:"banks"`
+}
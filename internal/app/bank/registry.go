@@ -0,0 +1,33 @@
+package bank
+
+import (
+	"log"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/app/bank/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/bank/handler"
+	"github.com/tunaiku/mobilebanking/internal/app/bank/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"go.uber.org/dig"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func() domain.BankDirectory {
+		return inmemory.NewInMemoryBankDirectory(alias.Banks)
+	})
+
+	container.Provide(func(bankDirectory domain.BankDirectory, authority *jwt.Authority) *handler.BankEndpoint {
+		return handler.NewBankEndpoint(bankDirectory, authority)
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(router chi.Router, endpoint *handler.BankEndpoint) {
+		log.Println("invoke bank startup ...")
+		endpoint.BindRoutes(router)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,26 @@
+package inmemory
+
+import (
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type InMemoryBankDirectory struct {
+	datastore []domain.Bank
+}
+
+func NewInMemoryBankDirectory(banks []domain.Bank) *InMemoryBankDirectory {
+	return &InMemoryBankDirectory{datastore: banks}
+}
+
+func (inmem *InMemoryBankDirectory) FindBanks() ([]domain.Bank, error) {
+	return inmem.datastore, nil
+}
+
+func (inmem *InMemoryBankDirectory) LoadBank(code string) (domain.Bank, error) {
+	for _, bank := range inmem.datastore {
+		if bank.Code == code {
+			return bank, nil
+		}
+	}
+	return domain.Bank{}, domain.ErrBankNotFound
+}
@@ -0,0 +1,19 @@
+package alias
+
+import (
+	"errors"
+
+	bankAlias "github.com/tunaiku/mobilebanking/internal/app/bank/alias"
+)
+
+const (
+	InternalBankCode string = bankAlias.InternalBankCode
+)
+
+var (
+	ErrMessageBeneficiaryNotFound      = errors.New("beneficiary not found")
+	ErrMessageBeneficiaryAlreadyExists = errors.New("beneficiary already exists")
+	ErrMessageAccountNotFound          = errors.New("account not found")
+	ErrMessageNicknameRequired         = errors.New("nickname is required")
+	ErrMessageBankNotFound             = errors.New("bank not found")
+)
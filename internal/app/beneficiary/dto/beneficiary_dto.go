@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type AddBeneficiaryDto struct {
+	AccountNumber string `json:"account_number"`
+	Nickname      string `json:"nickname"`
+	BankCode      string `json:"bank_code"`
+}
+
+func (dto *AddBeneficiaryDto) Bind(req *http.Request) error {
+	if err := json.NewDecoder(req.Body).Decode(dto); err != nil {
+		return err
+	}
+	return nil
+}
+
+type RenameBeneficiaryDto struct {
+	ID       string `json:"-"`
+	Nickname string `json:"nickname"`
+}
+
+func (dto *RenameBeneficiaryDto) Bind(req *http.Request) error {
+	if err := json.NewDecoder(req.Body).Decode(dto); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/dto"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/services"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+)
+
+type BeneficiaryEndpoint struct {
+	beneficiaryService services.BeneficiaryService
+	authority          *jwt.Authority
+}
+
+func NewBeneficiaryEndpoint(beneficiaryService services.BeneficiaryService, authority *jwt.Authority) *BeneficiaryEndpoint {
+	return &BeneficiaryEndpoint{beneficiaryService: beneficiaryService, authority: authority}
+}
+
+func (endpoint *BeneficiaryEndpoint) BindRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r = endpoint.authority.WrapChiRouterWithAuthorization(r)
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				next.ServeHTTP(w, r)
+			})
+		})
+		r.Post("/beneficiaries", endpoint.HandleAddBeneficiary)
+		r.Get("/beneficiaries", endpoint.HandleListBeneficiary)
+		r.Put("/beneficiaries/{id}", endpoint.HandleRenameBeneficiary)
+		r.Delete("/beneficiaries/{id}", endpoint.HandleDeleteBeneficiary)
+	})
+}
+
+func (endpoint *BeneficiaryEndpoint) HandleAddBeneficiary(w http.ResponseWriter, r *http.Request) {
+	requestDto := &dto.AddBeneficiaryDto{}
+	if err := requestDto.Bind(r); err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	beneficiary, err := endpoint.beneficiaryService.Add(requestDto, r.Context())
+	if err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	render.JSON(w, r, toBeneficiaryPayload(beneficiary))
+}
+
+func (endpoint *BeneficiaryEndpoint) HandleListBeneficiary(w http.ResponseWriter, r *http.Request) {
+	beneficiaries, err := endpoint.beneficiaryService.List(r.Context())
+	if err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	response := &ListBeneficiarySuccess{Beneficiaries: []BeneficiaryPayload{}}
+	for i := range beneficiaries {
+		response.Beneficiaries = append(response.Beneficiaries, toBeneficiaryPayload(&beneficiaries[i]))
+	}
+	render.JSON(w, r, response)
+}
+
+func (endpoint *BeneficiaryEndpoint) HandleRenameBeneficiary(w http.ResponseWriter, r *http.Request) {
+	requestDto := &dto.RenameBeneficiaryDto{}
+	if err := requestDto.Bind(r); err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+	requestDto.ID = chi.URLParam(r, "id")
+
+	beneficiary, err := endpoint.beneficiaryService.Rename(requestDto, r.Context())
+	if err != nil {
+		renderFailed(w, r, statusOf(err), err)
+		return
+	}
+
+	render.JSON(w, r, toBeneficiaryPayload(beneficiary))
+}
+
+func (endpoint *BeneficiaryEndpoint) HandleDeleteBeneficiary(w http.ResponseWriter, r *http.Request) {
+	if err := endpoint.beneficiaryService.Delete(chi.URLParam(r, "id"), r.Context()); err != nil {
+		renderFailed(w, r, statusOf(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toBeneficiaryPayload(beneficiary *domain.Beneficiary) BeneficiaryPayload {
+	return BeneficiaryPayload{
+		ID:            beneficiary.ID,
+		AccountNumber: beneficiary.AccountNumber,
+		Nickname:      beneficiary.Nickname,
+		BankCode:      beneficiary.BankCode,
+		CreatedAt:     beneficiary.CreatedAt,
+	}
+}
+
+func statusOf(err error) int {
+	if err == alias.ErrMessageBeneficiaryNotFound {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+func renderFailed(w http.ResponseWriter, r *http.Request, status int, err error) {
+	w.WriteHeader(status)
+	render.JSON(w, r, &BeneficiaryHandlerFailed{Message: err.Error()})
+}
@@ -0,0 +1,21 @@
+package handler
+
+import (
+	"time"
+)
+
+type BeneficiaryHandlerFailed struct {
+	Message string `json:"message"`
+}
+
+type BeneficiaryPayload struct {
+	ID            string    `json:"id"`
+	AccountNumber string    `json:"account_number"`
+	Nickname      string    `json:"nickname"`
+	BankCode      string    `json:"bank_code"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ListBeneficiarySuccess struct {
+	Beneficiaries []BeneficiaryPayload `json:"beneficiaries"`
+}
@@ -0,0 +1,41 @@
+package beneficiary
+
+import (
+	"log"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/handler"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/repository/postgres"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/services"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"go.uber.org/dig"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func() domain.BeneficiaryRepository {
+		return postgres.NewPostgresBeneficiaryRepository()
+	})
+
+	container.Provide(func(userSession domain.UserSessionHelper, repository domain.BeneficiaryRepository,
+		accountInformationService domain.AccountInformationService, bankDirectory domain.BankDirectory,
+		clearingGateway domain.ClearingGateway, unitOfWork domain.UnitOfWork) services.BeneficiaryService {
+		return services.NewBeneficiaryService(userSession, repository, accountInformationService, bankDirectory,
+			clearingGateway, unitOfWork)
+	})
+
+	container.Provide(func(beneficiaryService services.BeneficiaryService,
+		authority *jwt.Authority) *handler.BeneficiaryEndpoint {
+		return handler.NewBeneficiaryEndpoint(beneficiaryService, authority)
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(router chi.Router, endpoint *handler.BeneficiaryEndpoint) {
+		log.Println("invoke beneficiary startup ...")
+		endpoint.BindRoutes(router)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
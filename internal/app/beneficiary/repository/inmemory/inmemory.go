@@ -0,0 +1,57 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type InMemoryBeneficiaryRepository struct {
+	sync.Mutex
+	beneficiaries map[string]domain.Beneficiary
+}
+
+func NewInMemoryBeneficiaryRepository() *InMemoryBeneficiaryRepository {
+	return &InMemoryBeneficiaryRepository{beneficiaries: map[string]domain.Beneficiary{}}
+}
+
+func (inmem *InMemoryBeneficiaryRepository) Save(ctx context.Context, beneficiary *domain.Beneficiary) error {
+	inmem.Lock()
+	defer inmem.Unlock()
+	inmem.beneficiaries[beneficiary.ID] = *beneficiary
+	return nil
+}
+
+func (inmem *InMemoryBeneficiaryRepository) LoadBeneficiary(ctx context.Context, id string) (*domain.Beneficiary, error) {
+	inmem.Lock()
+	defer inmem.Unlock()
+	beneficiary, ok := inmem.beneficiaries[id]
+	if !ok {
+		return nil, domain.ErrBeneficiaryNotFound
+	}
+	return &beneficiary, nil
+}
+
+func (inmem *InMemoryBeneficiaryRepository) FindByUser(ctx context.Context, userID string) ([]domain.Beneficiary, error) {
+	inmem.Lock()
+	defer inmem.Unlock()
+	var beneficiaries []domain.Beneficiary
+	for _, beneficiary := range inmem.beneficiaries {
+		if beneficiary.UserID == userID {
+			beneficiaries = append(beneficiaries, beneficiary)
+		}
+	}
+	sort.Slice(beneficiaries, func(i, j int) bool {
+		return beneficiaries[i].Nickname < beneficiaries[j].Nickname
+	})
+	return beneficiaries, nil
+}
+
+func (inmem *InMemoryBeneficiaryRepository) Remove(ctx context.Context, id string) error {
+	inmem.Lock()
+	defer inmem.Unlock()
+	delete(inmem.beneficiaries, id)
+	return nil
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	appPg "github.com/tunaiku/mobilebanking/internal/pkg/pg"
+)
+
+type PostgresBeneficiaryRepository struct {
+}
+
+func NewPostgresBeneficiaryRepository() *PostgresBeneficiaryRepository {
+	return &PostgresBeneficiaryRepository{}
+}
+
+func (repo *PostgresBeneficiaryRepository) Save(ctx context.Context, beneficiary *domain.Beneficiary) error {
+	return appPg.FromContext(ctx).Save(beneficiary)
+}
+
+func (repo *PostgresBeneficiaryRepository) LoadBeneficiary(ctx context.Context, id string) (*domain.Beneficiary, error) {
+	beneficiary := &domain.Beneficiary{ID: id}
+	err := appPg.FromContext(ctx).Load(beneficiary)
+	if err == pg.ErrNoRows {
+		return nil, domain.ErrBeneficiaryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return beneficiary, nil
+}
+
+func (repo *PostgresBeneficiaryRepository) FindByUser(ctx context.Context, userID string) ([]domain.Beneficiary, error) {
+	var beneficiaries []domain.Beneficiary
+	err := appPg.FromContext(ctx).Query(&beneficiaries).
+		Where("user_id = ?", userID).
+		Order("nickname ASC").
+		Select()
+	return beneficiaries, err
+}
+
+func (repo *PostgresBeneficiaryRepository) Remove(ctx context.Context, id string) error {
+	return appPg.FromContext(ctx).Remove(&domain.Beneficiary{ID: id})
+}
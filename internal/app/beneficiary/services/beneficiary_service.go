@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/dto"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type BeneficiaryService interface {
+	Add(dto *dto.AddBeneficiaryDto, ctx context.Context) (*domain.Beneficiary, error)
+	List(ctx context.Context) ([]domain.Beneficiary, error)
+	Rename(dto *dto.RenameBeneficiaryDto, ctx context.Context) (*domain.Beneficiary, error)
+	Delete(id string, ctx context.Context) error
+}
+
+type BeneficiaryServiceImp struct {
+	userSession               domain.UserSessionHelper
+	repository                domain.BeneficiaryRepository
+	accountInformationService domain.AccountInformationService
+	bankDirectory             domain.BankDirectory
+	clearingGateway           domain.ClearingGateway
+	unitOfWork                domain.UnitOfWork
+}
+
+func NewBeneficiaryService(userSession domain.UserSessionHelper, repository domain.BeneficiaryRepository,
+	accountInformationService domain.AccountInformationService, bankDirectory domain.BankDirectory,
+	clearingGateway domain.ClearingGateway, unitOfWork domain.UnitOfWork) BeneficiaryService {
+	return &BeneficiaryServiceImp{userSession: userSession, repository: repository,
+		accountInformationService: accountInformationService, bankDirectory: bankDirectory,
+		clearingGateway: clearingGateway, unitOfWork: unitOfWork}
+}
+
+func (service *BeneficiaryServiceImp) Add(dto *dto.AddBeneficiaryDto, ctx context.Context) (*domain.Beneficiary, error) {
+	userSession, err := service.userSession.GetFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nickname := strings.TrimSpace(dto.Nickname)
+	if nickname == "" {
+		return nil, alias.ErrMessageNicknameRequired
+	}
+
+	bankCode := dto.BankCode
+	if bankCode == "" {
+		bankCode = alias.InternalBankCode
+	}
+
+	if err := service.checkAccount(bankCode, dto.AccountNumber, ctx); err != nil {
+		return nil, err
+	}
+
+	beneficiaries, err := service.repository.FindByUser(ctx, userSession.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, beneficiary := range beneficiaries {
+		if beneficiary.AccountNumber == dto.AccountNumber && beneficiary.BankCode == bankCode {
+			return nil, alias.ErrMessageBeneficiaryAlreadyExists
+		}
+	}
+
+	beneficiary := &domain.Beneficiary{
+		ID:            uuid.New().String(),
+		UserID:        userSession.ID,
+		AccountNumber: dto.AccountNumber,
+		Nickname:      nickname,
+		BankCode:      bankCode,
+		CreatedAt:     time.Now().UTC(),
+	}
+	err = service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return service.repository.Save(ctx, beneficiary)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return beneficiary, nil
+}
+
+func (service *BeneficiaryServiceImp) List(ctx context.Context) ([]domain.Beneficiary, error) {
+	userSession, err := service.userSession.GetFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return service.repository.FindByUser(ctx, userSession.ID)
+}
+
+func (service *BeneficiaryServiceImp) Rename(dto *dto.RenameBeneficiaryDto, ctx context.Context) (*domain.Beneficiary, error) {
+	beneficiary, err := service.loadOwned(dto.ID, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nickname := strings.TrimSpace(dto.Nickname)
+	if nickname == "" {
+		return nil, alias.ErrMessageNicknameRequired
+	}
+
+	beneficiary.Nickname = nickname
+	err = service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return service.repository.Save(ctx, beneficiary)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return beneficiary, nil
+}
+
+func (service *BeneficiaryServiceImp) Delete(id string, ctx context.Context) error {
+	beneficiary, err := service.loadOwned(id, ctx)
+	if err != nil {
+		return err
+	}
+	return service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return service.repository.Remove(ctx, beneficiary.ID)
+	})
+}
+
+func (service *BeneficiaryServiceImp) loadOwned(id string, ctx context.Context) (*domain.Beneficiary, error) {
+	userSession, err := service.userSession.GetFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	beneficiary, err := service.repository.LoadBeneficiary(ctx, id)
+	if err != nil || beneficiary.UserID != userSession.ID {
+		return nil, alias.ErrMessageBeneficiaryNotFound
+	}
+	return beneficiary, nil
+}
+
+// checkAccount looks internal accounts up in the core and accounts of other banks up through the clearing
+func (service *BeneficiaryServiceImp) checkAccount(bankCode string, accountNumber string, ctx context.Context) error {
+	bank, err := service.bankDirectory.LoadBank(bankCode)
+	if err != nil {
+		return alias.ErrMessageBankNotFound
+	}
+
+	if bank.Internal {
+		if !service.accountInformationService.IsAccountExists(accountNumber, ctx) {
+			return alias.ErrMessageAccountNotFound
+		}
+		return nil
+	}
+
+	if _, err := service.clearingGateway.InquireAccount(bankCode, accountNumber); err != nil {
+		return alias.ErrMessageAccountNotFound
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	bankAlias "github.com/tunaiku/mobilebanking/internal/app/bank/alias"
+	bankInmemory "github.com/tunaiku/mobilebanking/internal/app/bank/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/dto"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary/services"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/service/fake"
+	trxInmemory "github.com/tunaiku/mobilebanking/internal/app/transaction/repository/inmemory"
+)
+
+type stubUserSessionHelper struct {
+	user *domain.User
+}
+
+func (stub *stubUserSessionHelper) GetFromContext(ctx context.Context) (domain.UserSession, error) {
+	return domain.UserSession{User: stub.user}, nil
+}
+
+type stubClearingGateway struct {
+	domain.ClearingGateway
+}
+
+type beneficiaryFixture struct {
+	repository *inmemory.InMemoryBeneficiaryRepository
+	session    *stubUserSessionHelper
+	service    services.BeneficiaryService
+}
+
+func newBeneficiaryFixture() beneficiaryFixture {
+	fixture := beneficiaryFixture{
+		repository: inmemory.NewInMemoryBeneficiaryRepository(),
+		session:    &stubUserSessionHelper{user: &domain.User{ID: "user-1"}},
+	}
+	fixture.service = services.NewBeneficiaryService(fixture.session, fixture.repository,
+		fake.NewFakeAccountInformationService(), bankInmemory.NewInMemoryBankDirectory(bankAlias.Banks),
+		&stubClearingGateway{}, trxInmemory.NewInMemoryUnitOfWork())
+	return fixture
+}
+
+func TestBeneficiaryService_Should_SaveTheBeneficiaryForTheUser_When_TheAccountExists(t *testing.T) {
+	fixture := newBeneficiaryFixture()
+
+	beneficiary, err := fixture.service.Add(&dto.AddBeneficiaryDto{AccountNumber: "10002", Nickname: " Mom "},
+		context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := fixture.repository.LoadBeneficiary(context.Background(), beneficiary.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.UserID != "user-1" || saved.Nickname != "Mom" || saved.BankCode != alias.InternalBankCode {
+		t.Fatalf("the beneficiary should be saved for the user on the internal bank, got %+v", saved)
+	}
+}
+
+func TestBeneficiaryService_Should_RefuseTheBeneficiary_When_TheAccountIsUnknown(t *testing.T) {
+	fixture := newBeneficiaryFixture()
+
+	_, err := fixture.service.Add(&dto.AddBeneficiaryDto{AccountNumber: "99999", Nickname: "Unknown"}, context.Background())
+	if err != alias.ErrMessageAccountNotFound {
+		t.Fatalf("err should be `alias.ErrMessageAccountNotFound` but was %v", err)
+	}
+}
+
+func TestBeneficiaryService_Should_RefuseTheBeneficiary_When_TheUserAlreadySavedTheAccount(t *testing.T) {
+	fixture := newBeneficiaryFixture()
+	if _, err := fixture.service.Add(&dto.AddBeneficiaryDto{AccountNumber: "10002", Nickname: "Mom"}, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := fixture.service.Add(&dto.AddBeneficiaryDto{AccountNumber: "10002", Nickname: "Mother"}, context.Background())
+	if err != alias.ErrMessageBeneficiaryAlreadyExists {
+		t.Fatalf("err should be `alias.ErrMessageBeneficiaryAlreadyExists` but was %v", err)
+	}
+
+	fixture.session.user = &domain.User{ID: "user-2"}
+	if _, err := fixture.service.Add(&dto.AddBeneficiaryDto{AccountNumber: "10002", Nickname: "Mom"}, context.Background()); err != nil {
+		t.Fatalf("another user should be able to save the same account, got %v", err)
+	}
+}
+
+func TestBeneficiaryService_Should_ReturnNotFound_When_AnotherUserDeletesTheBeneficiary(t *testing.T) {
+	fixture := newBeneficiaryFixture()
+	beneficiary, err := fixture.service.Add(&dto.AddBeneficiaryDto{AccountNumber: "10002", Nickname: "Mom"}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fixture.session.user = &domain.User{ID: "user-2"}
+	if err := fixture.service.Delete(beneficiary.ID, context.Background()); err != alias.ErrMessageBeneficiaryNotFound {
+		t.Fatalf("err should be `alias.ErrMessageBeneficiaryNotFound` but was %v", err)
+	}
+	if _, err := fixture.repository.LoadBeneficiary(context.Background(), beneficiary.ID); err != nil {
+		t.Fatalf("the beneficiary should be kept, got %v", err)
+	}
+
+	fixture.session.user = &domain.User{ID: "user-1"}
+	if err := fixture.service.Delete(beneficiary.ID, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fixture.repository.LoadBeneficiary(context.Background(), beneficiary.ID); err != domain.ErrBeneficiaryNotFound {
+		t.Fatalf("err should be `domain.ErrBeneficiaryNotFound` but was %v", err)
+	}
+}
@@ -0,0 +1,24 @@
+package alias
+
+import (
+	"errors"
+	"time"
+)
+
+const SimulatedFailureReason string = "rejected by the beneficiary bank"
+
+const (
+	SettlementSuccess string = "success"
+	SettlementFailed  string = "failed"
+	SignatureHeader   string = "X-Signature"
+	TimestampHeader   string = "X-Timestamp"
+	// CallbackTolerance is how far the callback timestamp may drift from our clock
+	CallbackTolerance = 5 * time.Minute
+)
+
+var (
+	ErrMessageInvalidSignature        = errors.New("invalid signature")
+	ErrMessageCallbackIDRequired      = errors.New("callback_id is required")
+	ErrMessageReferenceRequired       = errors.New("either transaction_id or external_reference is required")
+	ErrMessageUnknownSettlementStatus = errors.New("status must be either success or failed")
+)
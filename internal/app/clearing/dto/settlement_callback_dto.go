@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/clearing/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type SettlementCallbackDto struct {
+	CallbackID        string    `json:"callback_id"`
+	TransactionID     string    `json:"transaction_id"`
+	ExternalReference string    `json:"external_reference"`
+	Status            string    `json:"status"`
+	FailureReason     string    `json:"failure_reason,omitempty"`
+	SettledAt         time.Time `json:"settled_at"`
+}
+
+func (dto *SettlementCallbackDto) Validate() error {
+	if dto.CallbackID == "" {
+		return alias.ErrMessageCallbackIDRequired
+	}
+	if dto.TransactionID == "" && dto.ExternalReference == "" {
+		return alias.ErrMessageReferenceRequired
+	}
+	if dto.Status != alias.SettlementSuccess && dto.Status != alias.SettlementFailed {
+		return alias.ErrMessageUnknownSettlementStatus
+	}
+	return nil
+}
+
+func (dto *SettlementCallbackDto) ToSettlement(payload string) domain.ClearingSettlement {
+	return domain.ClearingSettlement{
+		CallbackID:        dto.CallbackID,
+		Reference:         dto.TransactionID,
+		ExternalReference: dto.ExternalReference,
+		Success:           dto.Status == alias.SettlementSuccess,
+		FailureReason:     dto.FailureReason,
+		SettledAt:         dto.SettledAt,
+		Payload:           payload,
+	}
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/micro/go-micro/v3/errors"
+	"github.com/tunaiku/mobilebanking/internal/app/clearing/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/clearing/dto"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/signature"
+)
+
+type CallbackEndpoint struct {
+	listener domain.ClearingSettlementListener
+	secret   string
+}
+
+func NewCallbackEndpoint(listener domain.ClearingSettlementListener, secret string) *CallbackEndpoint {
+	return &CallbackEndpoint{listener: listener, secret: secret}
+}
+
+// BindRoutes exposes the callbacks to the clearing, they are authenticated by their HMAC signature
+// instead of a user token.
+func (endpoint *CallbackEndpoint) BindRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				next.ServeHTTP(w, r)
+			})
+		})
+		r.Post("/callbacks/settlement", endpoint.HandleSettlementCallback)
+	})
+}
+
+func (endpoint *CallbackEndpoint) HandleSettlementCallback(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(alias.TimestampHeader), 10, 64)
+	if err != nil || !signature.Verify(endpoint.secret, timestamp, body, r.Header.Get(alias.SignatureHeader),
+		alias.CallbackTolerance, time.Now()) {
+		renderFailed(w, r, http.StatusUnauthorized, alias.ErrMessageInvalidSignature)
+		return
+	}
+
+	callback := &dto.SettlementCallbackDto{}
+	if err := json.Unmarshal(body, callback); err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := callback.Validate(); err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := endpoint.listener.OnSettlement(r.Context(), callback.ToSettlement(string(body))); err != nil {
+		if e, ok := err.(*errors.Error); ok {
+			w.WriteHeader(int(e.Code))
+			render.JSON(w, r, &CallbackHandlerFailed{Message: e.Detail})
+			return
+		}
+		renderFailed(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	render.JSON(w, r, &SettlementCallbackAccepted{CallbackID: callback.CallbackID})
+}
+
+func renderFailed(w http.ResponseWriter, r *http.Request, status int, err error) {
+	w.WriteHeader(status)
+	render.JSON(w, r, &CallbackHandlerFailed{Message: err.Error()})
+}
@@ -0,0 +1,9 @@
+package handler
+
+type CallbackHandlerFailed struct {
+	Message string `json:"message"`
+}
+
+type SettlementCallbackAccepted struct {
+	CallbackID string `json:"callback_id"`
+}
@@ -0,0 +1,38 @@
+package clearing
+
+import (
+	"log"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/app/clearing/handler"
+	"github.com/tunaiku/mobilebanking/internal/app/clearing/service/simulated"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func(listener domain.ClearingSettlementListener, cfg *config.Config,
+		logger *zap.Logger) domain.ClearingGateway {
+		return simulated.NewSimulatedClearingGateway(listener, cfg.Clearing.SettlementDelay, cfg.Clearing.FailureRate,
+			logger)
+	})
+
+	container.Provide(func(listener domain.ClearingSettlementListener, cfg *config.Config) (*handler.CallbackEndpoint, error) {
+		if err := cfg.Clearing.RequireCallbackSecret(); err != nil {
+			return nil, err
+		}
+		return handler.NewCallbackEndpoint(listener, cfg.Clearing.CallbackSecret), nil
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(router chi.Router, endpoint *handler.CallbackEndpoint) {
+		log.Println("invoke clearing startup ...")
+		endpoint.BindRoutes(router)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
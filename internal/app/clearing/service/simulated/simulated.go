@@ -0,0 +1,90 @@
+package simulated
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/tunaiku/mobilebanking/internal/app/clearing/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/clearing/dto"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"go.uber.org/zap"
+)
+
+// SimulatedClearingGateway accepts every transfer and reports its settlement to the listener once the
+// delay elapsed, a share of the transfers given by the failure rate is rejected.
+type SimulatedClearingGateway struct {
+	listener    domain.ClearingSettlementListener
+	delay       time.Duration
+	failureRate float64
+	logger      *zap.Logger
+
+	mu     sync.Mutex
+	random *rand.Rand
+}
+
+func NewSimulatedClearingGateway(listener domain.ClearingSettlementListener, delay time.Duration,
+	failureRate float64, logger *zap.Logger) *SimulatedClearingGateway {
+	return &SimulatedClearingGateway{
+		listener:    listener,
+		delay:       delay,
+		failureRate: failureRate,
+		logger:      logger,
+		random:      rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// InquireAccount knows every numeric account number of 10 to 16 digits
+func (gateway *SimulatedClearingGateway) InquireAccount(bankCode string, accountNumber string) (domain.AccountInquiry, error) {
+	if len(accountNumber) < 10 || len(accountNumber) > 16 {
+		return domain.AccountInquiry{}, domain.ErrAccountNotFound
+	}
+	for _, r := range accountNumber {
+		if !unicode.IsDigit(r) {
+			return domain.AccountInquiry{}, domain.ErrAccountNotFound
+		}
+	}
+	return domain.AccountInquiry{
+		AccountNumber: accountNumber,
+		HolderName:    "Holder " + bankCode + " " + accountNumber[len(accountNumber)-4:],
+		Status:        domain.AccountActive,
+	}, nil
+}
+
+func (gateway *SimulatedClearingGateway) Submit(transfer domain.ClearingTransfer) (string, error) {
+	callback := dto.SettlementCallbackDto{
+		CallbackID:        uuid.New().String(),
+		TransactionID:     transfer.Reference,
+		ExternalReference: "SIM-" + uuid.New().String(),
+		Status:            alias.SettlementSuccess,
+	}
+	if gateway.fails() {
+		callback.Status = alias.SettlementFailed
+		callback.FailureReason = alias.SimulatedFailureReason
+	}
+
+	time.AfterFunc(gateway.delay, func() {
+		callback.SettledAt = time.Now().UTC()
+		payload, err := json.Marshal(callback)
+		if err != nil {
+			gateway.logger.Error("clearing settlement failed", zap.String("transaction_id", callback.TransactionID),
+				zap.Error(err))
+			return
+		}
+		if err := gateway.listener.OnSettlement(context.Background(), callback.ToSettlement(string(payload))); err != nil {
+			gateway.logger.Error("clearing settlement failed", zap.String("transaction_id", callback.TransactionID),
+				zap.Error(err))
+		}
+	})
+	return callback.ExternalReference, nil
+}
+
+func (gateway *SimulatedClearingGateway) fails() bool {
+	gateway.mu.Lock()
+	defer gateway.mu.Unlock()
+	return gateway.random.Float64() < gateway.failureRate
+}
@@ -0,0 +1,54 @@
+package simulated_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/clearing/service/simulated"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"go.uber.org/zap"
+)
+
+type settlementRecorder chan domain.ClearingSettlement
+
+func (recorder settlementRecorder) OnSettlement(ctx context.Context, settlement domain.ClearingSettlement) error {
+	recorder <- settlement
+	return nil
+}
+
+func submitAndWait(t *testing.T, failureRate float64) domain.ClearingSettlement {
+	recorder := make(settlementRecorder, 1)
+	gateway := simulated.NewSimulatedClearingGateway(recorder, time.Millisecond, failureRate, zap.NewNop())
+	if _, err := gateway.Submit(domain.ClearingTransfer{Reference: "a3289ce9"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case settlement := <-recorder:
+		return settlement
+	case <-time.After(time.Second):
+		t.Fatal("settlement should be reported")
+	}
+	return domain.ClearingSettlement{}
+}
+
+func TestSubmit_Should_ReportASuccessfulSettlement_When_TheFailureRateIsZero(t *testing.T) {
+	settlement := submitAndWait(t, 0)
+	if !settlement.Success || settlement.Reference != "a3289ce9" || settlement.CallbackID == "" {
+		t.Fatalf("settlement should be successful but got %+v", settlement)
+	}
+}
+
+func TestSubmit_Should_ReportAFailedSettlement_When_TheFailureRateIsOne(t *testing.T) {
+	settlement := submitAndWait(t, 1)
+	if settlement.Success || settlement.FailureReason == "" {
+		t.Fatalf("settlement should be failed with a reason but got %+v", settlement)
+	}
+}
+
+func TestInquireAccount_Should_ReturnErrAccountNotFound_When_TheAccountNumberIsNotNumeric(t *testing.T) {
+	gateway := simulated.NewSimulatedClearingGateway(make(settlementRecorder), time.Millisecond, 0, zap.NewNop())
+	if _, err := gateway.InquireAccount("BCA", "12345ABCDE"); err != domain.ErrAccountNotFound {
+		t.Fatal("err should be `domain.ErrAccountNotFound`")
+	}
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"github.com/micro/go-micro/v3/errors"
+)
+
+var (
+	ErrBankNotFound = errors.BadRequest("com.tunaiku.service.mbanking", "bank not found")
+)
+
+// Bank Represent a bank reachable for transfers, Internal is true for this bank itself
+type Bank struct {
+	Code     string
+	Name     string
+	Internal bool
+}
+
+type BankDirectory interface {
+	FindBanks() ([]Bank, error)
+	LoadBank(code string) (Bank, error)
+}
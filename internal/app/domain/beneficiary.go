@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro/go-micro/v3/errors"
+)
+
+var (
+	ErrBeneficiaryNotFound error = errors.NotFound("com.tunaiku.service.mbanking", "beneficiary not found")
+)
+
+// Beneficiary Represent a saved payee in the user's address book
+type Beneficiary struct {
+	ID            string
+	UserID        string
+	AccountNumber string
+	Nickname      string
+	BankCode      string
+	CreatedAt     time.Time
+}
+
+type BeneficiaryRepository interface {
+	Save(ctx context.Context, beneficiary *Beneficiary) error
+	LoadBeneficiary(ctx context.Context, id string) (*Beneficiary, error)
+	FindByUser(ctx context.Context, userID string) ([]Beneficiary, error)
+	Remove(ctx context.Context, id string) error
+}
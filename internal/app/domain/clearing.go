@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/micro/go-micro/v3/errors"
+)
+
+var (
+	ErrSettlementUnknownTransaction = errors.NotFound("com.tunaiku.service.mbanking", "no transaction matches the settlement")
+	ErrSettlementReplayed           = errors.Conflict("com.tunaiku.service.mbanking", "settlement callback already received")
+	ErrSettlementConflict           = errors.Conflict("com.tunaiku.service.mbanking", "transaction already settled with another outcome")
+)
+
+// ClearingTransfer Represent an interbank transfer submitted to the clearing, Reference is the transaction id.
+// Amount reaches the destination account while Fee is charged to the source account on top of it
+type ClearingTransfer struct {
+	Reference           string
+	SourceAccount       string
+	DestinationBankCode string
+	DestinationAccount  string
+	Amount              *big.Float
+	Fee                 *big.Float
+	Currency            string
+}
+
+// ClearingSettlement Represent the outcome of a clearing transfer, reported asynchronously. The transaction
+// is matched by Reference or ExternalReference, CallbackID identifies the notification itself
+type ClearingSettlement struct {
+	CallbackID        string
+	Reference         string
+	ExternalReference string
+	Success           bool
+	FailureReason     string
+	SettledAt         time.Time
+	Payload           string
+}
+
+// SettlementCallback Represent a settlement notification already received, kept to reject replays
+type SettlementCallback struct {
+	ID            string
+	TransactionID string
+	Payload       string
+	ReceivedAt    time.Time
+}
+
+type SettlementCallbackRepository interface {
+	// ExistsForTransaction reports whether a callback was already accepted for the transaction
+	ExistsForTransaction(ctx context.Context, transactionID string) (bool, error)
+	// Insert records the callback, a callback already recorded makes it fail with ErrSettlementReplayed
+	Insert(ctx context.Context, callback *SettlementCallback) error
+}
+
+type ClearingGateway interface {
+	InquireAccount(bankCode string, accountNumber string) (AccountInquiry, error)
+	// Submit hands the transfer over to the clearing and returns the clearing's own reference for it
+	Submit(transfer ClearingTransfer) (string, error)
+}
+
+type ClearingSettlementListener interface {
+	OnSettlement(ctx context.Context, settlement ClearingSettlement) error
+}
@@ -0,0 +1,66 @@
+package domain
+
+type FeeType int
+
+const (
+	NoFee FeeType = iota
+	FlatFee
+	PercentageFee
+	TieredFee
+)
+
+// FeeTier Represent the fee charged for amounts up to UpTo, the last tier has no upper bound when UpTo is zero
+type FeeTier struct {
+	UpTo float64
+	Fee  float64
+}
+
+// FeeRule Represent how the fee of a transaction code is computed, the first FreeTransfersPerMonth
+// transfers of a calendar month are not charged
+type FeeRule struct {
+	Type                  FeeType
+	Flat                  float64
+	Percentage            float64
+	MinimumFee            float64
+	MaximumFee            float64
+	Tiers                 []FeeTier
+	FreeTransfersPerMonth int
+}
+
+// Calculate returns the fee for the amount given how many transfers were already made this month
+func (rule FeeRule) Calculate(amount float64, transfersThisMonth int) float64 {
+	if transfersThisMonth < rule.FreeTransfersPerMonth {
+		return 0
+	}
+
+	switch rule.Type {
+	case FlatFee:
+		return rule.Flat
+	case PercentageFee:
+		fee := amount * rule.Percentage / 100
+		if fee < rule.MinimumFee {
+			fee = rule.MinimumFee
+		}
+		if rule.MaximumFee > 0 && fee > rule.MaximumFee {
+			fee = rule.MaximumFee
+		}
+		return fee
+	case TieredFee:
+		for _, tier := range rule.Tiers {
+			if tier.UpTo == 0 || amount <= tier.UpTo {
+				return tier.Fee
+			}
+		}
+	}
+	return 0
+}
+
+// TransactionQuote Represent the preview of what a transaction costs before it is created
+type TransactionQuote struct {
+	TransactionCode        string
+	Amount                 float64
+	Fee                    float64
+	Total                  float64
+	Currency               string
+	FreeTransfersRemaining int
+}
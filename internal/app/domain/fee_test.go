@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+func TestFeeRule_Should_ReturnZero_When_TheTransferIsWithinTheMonthlyWaiver(t *testing.T) {
+	rule := domain.FeeRule{Type: domain.FlatFee, Flat: 6500, FreeTransfersPerMonth: 2}
+	if fee := rule.Calculate(100000, 1); fee != 0 {
+		t.Fatalf("fee should be 0 but got %f", fee)
+	}
+	if fee := rule.Calculate(100000, 2); fee != 6500 {
+		t.Fatalf("fee should be 6500 but got %f", fee)
+	}
+}
+
+func TestFeeRule_Should_ClampThePercentage_When_TheFeeIsOutsideTheBounds(t *testing.T) {
+	rule := domain.FeeRule{Type: domain.PercentageFee, Percentage: 0.1, MinimumFee: 2500, MaximumFee: 25000}
+	expectations := map[float64]float64{
+		100000:   2500,
+		10000000: 10000,
+		50000000: 25000,
+	}
+	for amount, expected := range expectations {
+		if fee := rule.Calculate(amount, 0); fee != expected {
+			t.Fatalf("fee of %f should be %f but got %f", amount, expected, fee)
+		}
+	}
+}
+
+func TestFeeRule_Should_ChargeTheMatchingTier_When_TheRuleIsTiered(t *testing.T) {
+	rule := domain.FeeRule{Type: domain.TieredFee, Tiers: []domain.FeeTier{
+		{UpTo: 1000000, Fee: 2500},
+		{UpTo: 0, Fee: 6500},
+	}}
+	if fee := rule.Calculate(1000000, 0); fee != 2500 {
+		t.Fatalf("fee should be 2500 but got %f", fee)
+	}
+	if fee := rule.Calculate(1000001, 0); fee != 6500 {
+		t.Fatalf("fee should be 6500 but got %f", fee)
+	}
+}
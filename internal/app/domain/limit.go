@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/micro/go-micro/v3/errors"
+)
+
+var (
+	ErrLimitNotFound = errors.BadRequest("com.tunaiku.service.mbanking", "transaction limit not found")
+)
+
+// TransactionLimit Represent the caps applied to a transaction code for the accounts of a tier,
+// the tier is the product type of the source account
+type TransactionLimit struct {
+	Tier              string
+	TransactionCode   string
+	MaxPerTransaction float64
+	MaxDailyAmount    float64
+	MaxDailyCount     int
+}
+
+// LimitUsage Represent what the user already spent today against a limit
+type LimitUsage struct {
+	Limit      TransactionLimit
+	UsedAmount float64
+	UsedCount  int
+}
+
+func (usage LimitUsage) RemainingAmount() float64 {
+	if remaining := usage.Limit.MaxDailyAmount - usage.UsedAmount; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+func (usage LimitUsage) RemainingCount() int {
+	if remaining := usage.Limit.MaxDailyCount - usage.UsedCount; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+type TransactionLimitRepository interface {
+	FindLimit(tier string, transactionCode string) (TransactionLimit, error)
+	FindLimitsByTier(tier string) ([]TransactionLimit, error)
+}
+
+type TransactionLimitService interface {
+	// CheckLimit has to run in the unit of work saving the transaction, it locks the user's usage of the code
+	// until the unit of work ends so concurrent transfers are checked one after the other
+	CheckLimit(userID string, sourceAccount string, transactionCode string, amount float64, fee float64,
+		ctx context.Context) error
+	GetUsage(userID string, accountNumber string, ctx context.Context) ([]LimitUsage, error)
+}
@@ -0,0 +1,35 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+func TestLimitUsage_Should_ReturnTheRemainingAllowance_When_TheLimitIsNotReached(t *testing.T) {
+	usage := domain.LimitUsage{
+		Limit:      domain.TransactionLimit{MaxDailyAmount: 50000000, MaxDailyCount: 20},
+		UsedAmount: 12500000,
+		UsedCount:  3,
+	}
+	if remaining := usage.RemainingAmount(); remaining != 37500000 {
+		t.Fatalf("remaining amount should be 37500000 but got %f", remaining)
+	}
+	if remaining := usage.RemainingCount(); remaining != 17 {
+		t.Fatalf("remaining count should be 17 but got %d", remaining)
+	}
+}
+
+func TestLimitUsage_Should_ReturnZero_When_TheUsageIsOverTheLimit(t *testing.T) {
+	usage := domain.LimitUsage{
+		Limit:      domain.TransactionLimit{MaxDailyAmount: 50000000, MaxDailyCount: 20},
+		UsedAmount: 60000000,
+		UsedCount:  21,
+	}
+	if remaining := usage.RemainingAmount(); remaining != 0 {
+		t.Fatalf("remaining amount should be 0 but got %f", remaining)
+	}
+	if remaining := usage.RemainingCount(); remaining != 0 {
+		t.Fatalf("remaining count should be 0 but got %d", remaining)
+	}
+}
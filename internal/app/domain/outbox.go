@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxMessage Represent a transfer waiting to be booked by the core. It is written in the same database
+// transaction as the state change of its transaction so the posting survives a crash, and it is delivered
+// with the transaction id as idempotency key so a redelivery never moves the money twice.
+// ProcessedAt is set once the message was delivered or abandoned after too many attempts.
+type OutboxMessage struct {
+	ID            string
+	TransactionID string
+	Payload       string
+	Attempts      int
+	LastError     string
+	NextAttemptAt time.Time
+	ProcessedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+type OutboxRepository interface {
+	// Claim leases a pending message until leaseUntil and counts the attempt, it returns false when the
+	// message was processed or is leased by someone else
+	Claim(ctx context.Context, message *OutboxMessage, now time.Time, leaseUntil time.Time) (bool, error)
+	FindPending(ctx context.Context, now time.Time, limit int) ([]OutboxMessage, error)
+	Save(ctx context.Context, message *OutboxMessage) error
+}
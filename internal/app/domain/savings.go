@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"math/big"
 	"time"
 
@@ -12,9 +13,46 @@ var (
 	ErrAccountNotFound           = errors.BadRequest("com.tunaiku.service.cbs", "account not found")
 )
 
+// TransactionRejectedError is returned by TransactionService.CreateTransaction when the core refused the
+// posting itself, such as for insufficient funds or a closed account, so sending it again cannot succeed
+type TransactionRejectedError struct {
+	Reason string
+}
+
+func (err *TransactionRejectedError) Error() string {
+	return "rejected by the core: " + err.Reason
+}
+
+type AccountStatus int
+
+const (
+	UnknownAccountStatus AccountStatus = iota
+	AccountActive
+	AccountDormant
+	AccountBlocked
+	AccountClosed
+)
+
+type AccountInquiry struct {
+	AccountNumber string
+	HolderName    string
+	Status        AccountStatus
+}
+
+type Account struct {
+	AccountNumber    string
+	HolderName       string
+	AvailableBalance *big.Float
+	LedgerBalance    *big.Float
+	Currency         string
+	ProductType      string
+	Status           AccountStatus
+}
+
 type TransactionDetail struct {
 	Code          string
 	MinimumAmount *big.Float
+	Fee           FeeRule
 }
 
 type TransactionPrivileges struct {
@@ -26,19 +64,56 @@ type TransactionCreation struct {
 	DestinationAccount string
 	TransactionCode    string
 	Amount             *big.Float
+	Fee                *big.Float
 	Currency           string
 	TransactionDate    *time.Time
+	Reference          string
+}
+
+// AccountPosting Represent a movement booked by the core on an account, Amount is negative for debits
+type AccountPosting struct {
+	AccountNumber string
+	Reference     string
+	Description   string
+	Amount        *big.Float
+	PostedAt      time.Time
+}
+
+type StatementSummary struct {
+	AccountNumber  string
+	Currency       string
+	From           time.Time
+	To             time.Time
+	OpeningBalance *big.Float
+	ClosingBalance *big.Float
+}
+
+type StatementEntry struct {
+	PostedAt    time.Time
+	Reference   string
+	Description string
+	Debit       *big.Float
+	Credit      *big.Float
+	Balance     *big.Float
 }
 
 type AccountInformationService interface {
-	IsAccountExists(accountNumber string) bool
-	GetTransactionPrivileges(accountNumber string) (TransactionPrivileges, error)
+	IsAccountExists(accountNumber string, ctx context.Context) bool
+	GetTransactionPrivileges(accountNumber string, ctx context.Context) (TransactionPrivileges, error)
+	InquireAccount(accountNumber string, ctx context.Context) (AccountInquiry, error)
+	GetAccount(accountNumber string, ctx context.Context) (Account, error)
 }
 
 type TransactionInformationService interface {
-	FindTransactionDetailByCode(code string) (TransactionDetail, error)
+	FindTransactionDetailByCode(code string, ctx context.Context) (TransactionDetail, error)
+	FindPostings(accountNumber string, from time.Time, to time.Time, ctx context.Context) ([]AccountPosting, error)
 }
 
 type TransactionService interface {
-	CreateTransaction(transactionCreation TransactionCreation) error
+	CreateTransaction(transactionCreation TransactionCreation, ctx context.Context) error
+}
+
+type StatementService interface {
+	Summarize(accountNumber string, from time.Time, to time.Time, ctx context.Context) (StatementSummary, error)
+	EachEntry(summary StatementSummary, fn func(entry StatementEntry) error, ctx context.Context) error
 }
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/micro/go-micro/v3/errors"
+)
+
+var (
+	ErrStandingOrderNotFound  error = errors.NotFound("com.tunaiku.service.mbanking", "standing order not found")
+	ErrStandingOrderLeaseLost error = errors.Conflict("com.tunaiku.service.mbanking", "standing order lease lost")
+)
+
+type RecurrenceFrequency int
+
+const (
+	UnknownRecurrenceFrequency RecurrenceFrequency = iota
+	DailyRecurrence
+	WeeklyRecurrence
+	MonthlyRecurrence
+)
+
+type StandingOrderState int
+
+const (
+	UnknownStandingOrderState StandingOrderState = iota
+	StandingOrderWaitAuthorization
+	StandingOrderActive
+	StandingOrderCancelled
+	StandingOrderCompleted
+)
+
+type StandingOrderRunStatus int
+
+const (
+	UnknownStandingOrderRunStatus StandingOrderRunStatus = iota
+	StandingOrderRunExecuted
+	StandingOrderRunFailed
+	StandingOrderRunSkipped
+)
+
+// StandingOrder Represent a recurring transfer mandate authorized once by the user
+type StandingOrder struct {
+	ID                      string
+	UserID                  string
+	State                   StandingOrderState
+	AuthorizationMethod     AuthorizationMethod
+	RequiredAuthorizations  []AuthorizationMethod `pg:",array"`
+	CompletedAuthorizations []AuthorizationMethod `pg:",array"`
+	TransactionCode         string
+	Amount                  float64
+	SourceAccount           string
+	DestinationAccount      string
+	Frequency               RecurrenceFrequency
+	StartDate               time.Time
+	EndDate                 *time.Time
+	NextOccurrence          int
+	NextRunAt               *time.Time
+	// LeaseOwner identifies the run executing the order, it is empty while no run holds it
+	LeaseOwner string
+	CreatedAt  time.Time
+}
+
+// PendingAuthorizations lists the methods the authorization policy required for the mandate that the user did
+// not verify yet, mandates created before the policy applied to them only require their AuthorizationMethod
+func (order *StandingOrder) PendingAuthorizations() []AuthorizationMethod {
+	return pendingAuthorizations(order.AuthorizationMethod, order.RequiredAuthorizations, order.CompletedAuthorizations)
+}
+
+// Occurrence returns the n-th (zero based) execution time of the standing order.
+// Monthly orders keep the day of the start date and fall back to the last day of
+// shorter months, so an order starting on the 31st runs on Feb 28th (or 29th).
+func (order *StandingOrder) Occurrence(n int) time.Time {
+	start := order.StartDate
+	switch order.Frequency {
+	case DailyRecurrence:
+		return start.AddDate(0, 0, n)
+	case WeeklyRecurrence:
+		return start.AddDate(0, 0, 7*n)
+	case MonthlyRecurrence:
+		firstOfMonth := time.Date(start.Year(), start.Month()+time.Month(n), 1,
+			start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+		day := start.Day()
+		if last := firstOfMonth.AddDate(0, 1, -1).Day(); day > last {
+			day = last
+		}
+		return firstOfMonth.AddDate(0, 0, day-1)
+	default:
+		return start
+	}
+}
+
+// IsWithinPeriod it would return true if the given time is not after the end date
+func (order *StandingOrder) IsWithinPeriod(at time.Time) bool {
+	return order.EndDate == nil || !at.After(*order.EndDate)
+}
+
+// StandingOrderRun Represent the outcome of a single standing order occurrence
+type StandingOrderRun struct {
+	ID              string
+	StandingOrderID string
+	Occurrence      int
+	ScheduledAt     time.Time
+	Status          StandingOrderRunStatus
+	TransactionID   string
+	Reason          string
+	CreatedAt       time.Time
+}
+
+type StandingOrderRepository interface {
+	Insert(ctx context.Context, order *StandingOrder) error
+	FindByID(ctx context.Context, id string) (*StandingOrder, error)
+	FindByUser(ctx context.Context, userID string) ([]StandingOrder, error)
+	// Update writes the terms, the authorizations, the state and the next run changed by the user, the
+	// progress of the executions and the lease are left to the runner
+	Update(ctx context.Context, order *StandingOrder) error
+	FindDue(ctx context.Context, now time.Time) ([]StandingOrder, error)
+	// Claim leases a due active order to owner until leaseUntil and reloads it, it returns false when the
+	// order is no longer due or another run claimed it first
+	Claim(ctx context.Context, order *StandingOrder, owner string, now time.Time, leaseUntil time.Time) (bool, error)
+	// Advance records the progress of the run holding the lease and releases it, it returns
+	// ErrStandingOrderLeaseLost when another run holds the order. The state and the next run are only written
+	// while the order is still active, so a cancellation or an update made during the run is kept
+	Advance(ctx context.Context, order *StandingOrder) error
+	InsertRun(ctx context.Context, run *StandingOrderRun) error
+	FindRuns(ctx context.Context, standingOrderID string) ([]StandingOrderRun, error)
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+func TestOccurrence_Should_FallBackToTheLastDayOfTheMonth_When_TheMonthIsShorterThanTheStartDay(t *testing.T) {
+	order := &domain.StandingOrder{
+		Frequency: domain.MonthlyRecurrence,
+		StartDate: time.Date(2021, time.January, 31, 9, 0, 0, 0, time.UTC),
+	}
+	expectations := []time.Time{
+		time.Date(2021, time.January, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, time.February, 28, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, time.March, 31, 9, 0, 0, 0, time.UTC),
+		time.Date(2021, time.April, 30, 9, 0, 0, 0, time.UTC),
+	}
+	for n, expected := range expectations {
+		if occurrence := order.Occurrence(n); !occurrence.Equal(expected) {
+			t.Fatalf("occurrence %d should be %s but got %s", n, expected, occurrence)
+		}
+	}
+}
+
+func TestOccurrence_Should_UseTheLeapDay_When_TheYearIsALeapYear(t *testing.T) {
+	order := &domain.StandingOrder{
+		Frequency: domain.MonthlyRecurrence,
+		StartDate: time.Date(2023, time.December, 30, 9, 0, 0, 0, time.UTC),
+	}
+	expected := time.Date(2024, time.February, 29, 9, 0, 0, 0, time.UTC)
+	if occurrence := order.Occurrence(2); !occurrence.Equal(expected) {
+		t.Fatalf("occurrence should be %s but got %s", expected, occurrence)
+	}
+}
+
+func TestOccurrence_Should_AddSevenDays_When_TheFrequencyIsWeekly(t *testing.T) {
+	order := &domain.StandingOrder{
+		Frequency: domain.WeeklyRecurrence,
+		StartDate: time.Date(2021, time.January, 31, 9, 0, 0, 0, time.UTC),
+	}
+	expected := time.Date(2021, time.February, 14, 9, 0, 0, 0, time.UTC)
+	if occurrence := order.Occurrence(2); !occurrence.Equal(expected) {
+		t.Fatalf("occurrence should be %s but got %s", expected, occurrence)
+	}
+}
+
+func TestIsWithinPeriod_Should_ReturnFalse_When_TheTimeIsAfterTheEndDate(t *testing.T) {
+	endDate := time.Date(2021, time.March, 1, 0, 0, 0, 0, time.UTC)
+	order := &domain.StandingOrder{EndDate: &endDate}
+	if order.IsWithinPeriod(endDate.Add(time.Second)) {
+		t.Fatal("time after the end date shouldn't be within the period")
+	}
+}
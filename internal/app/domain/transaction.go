@@ -1,7 +1,17 @@
 package domain
 
 import (
+	"context"
 	"time"
+
+	"github.com/micro/go-micro/v3/errors"
+)
+
+var (
+	ErrTransactionNotFound error = errors.NotFound("com.tunaiku.service.mbanking", "transaction not found")
+	// ErrTransactionStateChanged is returned when the transaction left the state a change was made from,
+	// e.g. because another scheduler instance already posted it
+	ErrTransactionStateChanged error = errors.Conflict("com.tunaiku.service.mbanking", "transaction state changed")
 )
 
 type TransactionState int
@@ -11,6 +21,8 @@ const (
 	WaitAuthorization
 	Failed
 	Success
+	Scheduled
+	Processing
 )
 
 type AuthorizationMethod int
@@ -21,14 +33,133 @@ const (
 	PinAuthorization
 )
 
+// AuthorizationLevel is the strength of authorization a transaction demands, decided by the AuthorizationPolicy
+type AuthorizationLevel int
+
+const (
+	SingleFactorAuthorization AuthorizationLevel = iota
+	StepUpAuthorization
+	MultiFactorAuthorization
+)
+
 type Transaction struct {
+	ID                      string
+	UserID                  string
+	State                   TransactionState
+	AuthorizationMethod     AuthorizationMethod
+	RequiredAuthorizations  []AuthorizationMethod `pg:",array"`
+	CompletedAuthorizations []AuthorizationMethod `pg:",array"`
+	TransactionCode         string
+	Amount                  float64
+	Fee                     float64
+	SourceAccount           string
+	DestinationAccount      string
+	DestinationBankCode     string
+	DestinationName         string
+	ExecutionDate           *time.Time
+	FailureReason           string
+	ExternalReference       string
+	CreatedAt               time.Time
+}
+
+// BookedAt is when the transfer moves the money, on its execution date when it was scheduled
+func (transaction *Transaction) BookedAt() time.Time {
+	if transaction.ExecutionDate != nil {
+		return *transaction.ExecutionDate
+	}
+	return transaction.CreatedAt
+}
+
+// PendingAuthorizations lists the required methods the user did not verify yet, transactions created before
+// step-up authorization only require their AuthorizationMethod
+func (transaction *Transaction) PendingAuthorizations() []AuthorizationMethod {
+	return pendingAuthorizations(transaction.AuthorizationMethod, transaction.RequiredAuthorizations,
+		transaction.CompletedAuthorizations)
+}
+
+func pendingAuthorizations(authorizationMethod AuthorizationMethod, required []AuthorizationMethod,
+	completed []AuthorizationMethod) []AuthorizationMethod {
+	if len(required) == 0 {
+		required = []AuthorizationMethod{authorizationMethod}
+	}
+
+	var pending []AuthorizationMethod
+	for _, method := range required {
+		if !isAuthorizedBy(method, completed) {
+			pending = append(pending, method)
+		}
+	}
+	return pending
+}
+
+func isAuthorizedBy(method AuthorizationMethod, completed []AuthorizationMethod) bool {
+	for _, authorized := range completed {
+		if authorized == method {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorizationRequest Represent what the AuthorizationPolicy evaluates to decide the authorization level
+type AuthorizationRequest struct {
+	UserID               string
+	TransactionCode      string
+	Amount               float64
+	DestinationAccount   string
+	FirstTimeDestination bool
+	RiskLevel            UserRiskLevel
+}
+
+type AuthorizationPolicy interface {
+	Evaluate(request AuthorizationRequest) AuthorizationLevel
+}
+
+// TransactionEvent is an append-only record of a single transaction state change
+type TransactionEvent struct {
 	ID                  string
-	UserID              string
-	State               TransactionState
+	TransactionID       string
+	FromState           TransactionState
+	ToState             TransactionState
+	ActorUserID         string
 	AuthorizationMethod AuthorizationMethod
-	TransactionCode     string
-	Amount              float64
-	SourceAccount       string
-	DestinationAccount  string
+	FailureReason       string
+	ClientIP            string
+	Payload             string
 	CreatedAt           time.Time
 }
+
+type TransactionRepository interface {
+	Save(ctx context.Context, transaction *Transaction) error
+	FindByID(ctx context.Context, id string) (*Transaction, error)
+	FindByUser(ctx context.Context, userID string) ([]Transaction, error)
+	// UpdateState persists the transaction in its new state together with the event describing the change,
+	// it fails with ErrTransactionStateChanged when the stored transaction is no longer in the event's FromState
+	UpdateState(ctx context.Context, transaction *Transaction, event TransactionEvent) error
+	// UpdateExternalReference only writes the external reference so a concurrent state change is not overwritten
+	UpdateExternalReference(ctx context.Context, transaction *Transaction) error
+	FindByExternalReference(ctx context.Context, externalReference string) (*Transaction, error)
+	FindDueScheduled(ctx context.Context, now time.Time) ([]Transaction, error)
+	// HasPaidDestination tells whether the user already paid the destination account successfully
+	HasPaidDestination(ctx context.Context, userID string, bankCode string, accountNumber string) (bool, error)
+	// CountSince counts the user's non-failed transactions of the code created since the given time
+	CountSince(ctx context.Context, userID string, transactionCode string, since time.Time) (int, error)
+	// UsageSince sums the amount and fee of the user's transactions of the code created since the given time
+	// and counts them, only the booked, in-flight and recently created transactions waiting for their
+	// authorization are counted
+	UsageSince(ctx context.Context, userID string, transactionCode string, since time.Time) (float64, int, error)
+	// LockUsage serializes the units of work checking the usage of the user for the code, the lock is held
+	// until the unit of work carried by ctx ends
+	LockUsage(ctx context.Context, userID string, transactionCode string) error
+	// NetMovementOfAccount sums the successful transactions credited to the account booked in [from, to)
+	// less the ones debited from it, fee included
+	NetMovementOfAccount(ctx context.Context, accountNumber string, from time.Time, to time.Time) (float64, error)
+	// ForEachOfAccount calls fn for the successful transactions of the account booked in [from, to) in
+	// booking order, reading them one at a time
+	ForEachOfAccount(ctx context.Context, accountNumber string, from time.Time, to time.Time,
+		fn func(transaction *Transaction) error) error
+	// FindExistingIDs returns which of the ids are transactions of this service
+	FindExistingIDs(ctx context.Context, ids []string) ([]string, error)
+	FindEvents(ctx context.Context, transactionID string) ([]TransactionEvent, error)
+	CountByState(ctx context.Context, state TransactionState) (int, error)
+}
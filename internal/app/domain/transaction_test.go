@@ -0,0 +1,27 @@
+package domain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+func TestPendingAuthorizations_Should_ReturnTheMethodsNotVerifiedYet_When_SeveralMethodsAreRequired(t *testing.T) {
+	transaction := &domain.Transaction{
+		RequiredAuthorizations:  []domain.AuthorizationMethod{domain.PinAuthorization, domain.OtpAuthorization},
+		CompletedAuthorizations: []domain.AuthorizationMethod{domain.PinAuthorization},
+	}
+	expected := []domain.AuthorizationMethod{domain.OtpAuthorization}
+	if pending := transaction.PendingAuthorizations(); !reflect.DeepEqual(pending, expected) {
+		t.Fatalf("pending authorizations should be %v but got %v", expected, pending)
+	}
+}
+
+func TestPendingAuthorizations_Should_FallBackToTheAuthorizationMethod_When_NoMethodIsRequired(t *testing.T) {
+	transaction := &domain.Transaction{AuthorizationMethod: domain.PinAuthorization}
+	expected := []domain.AuthorizationMethod{domain.PinAuthorization}
+	if pending := transaction.PendingAuthorizations(); !reflect.DeepEqual(pending, expected) {
+		t.Fatalf("pending authorizations should be %v but got %v", expected, pending)
+	}
+}
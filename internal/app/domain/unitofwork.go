@@ -0,0 +1,10 @@
+package domain
+
+import (
+	"context"
+)
+
+// UnitOfWork runs fn so the writes the repositories make with the context given to fn commit or roll back together
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -48,6 +48,31 @@ type User struct {
 	Username                        string
 	Password                        string
 	ConfiguredTransactionCredential *ConfiguredCredential
+	RiskLevel                       UserRiskLevel
+	Role                            UserRole
+}
+
+type UserRiskLevel int
+
+const (
+	NormalRisk UserRiskLevel = iota
+	HighRisk
+)
+
+// UserRole tells the customers apart from the staff investigating their transactions
+type UserRole int
+
+const (
+	CustomerRole UserRole = iota
+	SupportRole
+)
+
+//UserAccount Represent the link between a user and an account the user may operate,
+//an account can be linked to several users (joint accounts) and a user to several accounts
+type UserAccount struct {
+	UserID        string
+	AccountNumber string
+	Primary       bool
 }
 
 type FindUserResult struct {
@@ -62,6 +87,11 @@ type UserRepository interface {
 	LoadByUsername(username string) (*User, error)
 }
 
+type UserAccountRepository interface {
+	FindAccountsByUser(userID string) ([]UserAccount, error)
+	FindUsersByAccount(accountNumber string) ([]UserAccount, error)
+}
+
 type UserService interface {
 	FindUser(userId string) (FindUserResult, error)
 }
@@ -0,0 +1,25 @@
+package alias
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+// DailyLimitLocation is the timezone in which the daily usage resets at midnight
+var DailyLimitLocation = time.FixedZone("WIB", 7*60*60)
+
+var DefaultLimits = []domain.TransactionLimit{
+	{Tier: "savings", TransactionCode: "T001", MaxPerTransaction: 25000000, MaxDailyAmount: 50000000, MaxDailyCount: 20},
+	{Tier: "savings", TransactionCode: "T002", MaxPerTransaction: 10000000, MaxDailyAmount: 25000000, MaxDailyCount: 10},
+	{Tier: "current", TransactionCode: "T001", MaxPerTransaction: 100000000, MaxDailyAmount: 250000000, MaxDailyCount: 50},
+	{Tier: "current", TransactionCode: "T002", MaxPerTransaction: 50000000, MaxDailyAmount: 100000000, MaxDailyCount: 25},
+}
+
+var (
+	ErrMessagePerTransactionLimitExceeded = errors.New("amount exceeds the per transaction limit")
+	ErrMessageDailyAmountLimitExceeded    = errors.New("amount exceeds the daily transaction limit")
+	ErrMessageDailyCountLimitExceeded     = errors.New("daily transaction count limit reached")
+	ErrMessageAccountNotLinked            = errors.New("account is not linked to the user")
+)
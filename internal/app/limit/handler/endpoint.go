@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/limit/alias"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+)
+
+type LimitEndpoint struct {
+	userSessionHelper     domain.UserSessionHelper
+	limitService          domain.TransactionLimitService
+	userAccountRepository domain.UserAccountRepository
+	authority             *jwt.Authority
+}
+
+func NewLimitEndpoint(userSessionHelper domain.UserSessionHelper, limitService domain.TransactionLimitService,
+	userAccountRepository domain.UserAccountRepository, authority *jwt.Authority) *LimitEndpoint {
+	return &LimitEndpoint{userSessionHelper: userSessionHelper, limitService: limitService,
+		userAccountRepository: userAccountRepository, authority: authority}
+}
+
+func (endpoint *LimitEndpoint) BindRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r = endpoint.authority.WrapChiRouterWithAuthorization(r)
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				next.ServeHTTP(w, r)
+			})
+		})
+		r.Get("/limits", endpoint.HandleGetLimits)
+	})
+}
+
+// HandleGetLimits shows today's usage against the limits of the account given by the account_number
+// query parameter, the user's primary account when it is omitted.
+func (endpoint *LimitEndpoint) HandleGetLimits(w http.ResponseWriter, r *http.Request) {
+	userSession, err := endpoint.userSessionHelper.GetFromContext(r.Context())
+	if err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	accountNumber := r.URL.Query().Get("account_number")
+	if accountNumber == "" {
+		accountNumber = userSession.AccountReference
+	} else if !endpoint.isLinked(userSession, accountNumber) {
+		renderFailed(w, r, http.StatusNotFound, alias.ErrMessageAccountNotLinked)
+		return
+	}
+
+	usages, err := endpoint.limitService.GetUsage(userSession.ID, accountNumber, r.Context())
+	if err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	response := &GetLimitsSuccess{AccountNumber: accountNumber, Limits: []LimitUsagePayload{}}
+	for _, usage := range usages {
+		response.Tier = usage.Limit.Tier
+		response.Limits = append(response.Limits, LimitUsagePayload{
+			TransactionCode:   usage.Limit.TransactionCode,
+			MaxPerTransaction: usage.Limit.MaxPerTransaction,
+			MaxDailyAmount:    usage.Limit.MaxDailyAmount,
+			MaxDailyCount:     usage.Limit.MaxDailyCount,
+			UsedAmount:        usage.UsedAmount,
+			UsedCount:         usage.UsedCount,
+			RemainingAmount:   usage.RemainingAmount(),
+			RemainingCount:    usage.RemainingCount(),
+		})
+	}
+	render.JSON(w, r, response)
+}
+
+func (endpoint *LimitEndpoint) isLinked(userSession domain.UserSession, accountNumber string) bool {
+	linkedAccounts, err := endpoint.userAccountRepository.FindAccountsByUser(userSession.ID)
+	if err != nil {
+		return false
+	}
+	for _, linked := range linkedAccounts {
+		if linked.AccountNumber == accountNumber {
+			return true
+		}
+	}
+	return false
+}
+
+func renderFailed(w http.ResponseWriter, r *http.Request, status int, err error) {
+	w.WriteHeader(status)
+	render.JSON(w, r, &LimitHandlerFailed{Message: err.Error()})
+}
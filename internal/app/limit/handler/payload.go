@@ -0,0 +1,22 @@
+package handler
+
+type LimitHandlerFailed struct {
+	Message string `json:"message"`
+}
+
+type LimitUsagePayload struct {
+	TransactionCode   string  `json:"transaction_code"`
+	MaxPerTransaction float64 `json:"max_per_transaction"`
+	MaxDailyAmount    float64 `json:"max_daily_amount"`
+	MaxDailyCount     int     `json:"max_daily_count"`
+	UsedAmount        float64 `json:"used_amount"`
+	UsedCount         int     `json:"used_count"`
+	RemainingAmount   float64 `json:"remaining_amount"`
+	RemainingCount    int     `json:"remaining_count"`
+}
+
+type GetLimitsSuccess struct {
+	AccountNumber string              `json:"account_number"`
+	Tier          string              `json:"tier"`
+	Limits        []LimitUsagePayload `json:"limits"`
+}
@@ -0,0 +1,41 @@
+package limit
+
+import (
+	"log"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/limit/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/limit/handler"
+	"github.com/tunaiku/mobilebanking/internal/app/limit/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/limit/services"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"go.uber.org/dig"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func() domain.TransactionLimitRepository {
+		return inmemory.NewInMemoryTransactionLimitRepository(alias.DefaultLimits)
+	})
+
+	container.Provide(func(limitRepository domain.TransactionLimitRepository,
+		accountInformation domain.AccountInformationService,
+		transactionRepository domain.TransactionRepository) domain.TransactionLimitService {
+		return services.NewTransactionLimitService(limitRepository, accountInformation, transactionRepository)
+	})
+
+	container.Provide(func(userSessionHelper domain.UserSessionHelper, limitService domain.TransactionLimitService,
+		userAccountRepository domain.UserAccountRepository, authority *jwt.Authority) *handler.LimitEndpoint {
+		return handler.NewLimitEndpoint(userSessionHelper, limitService, userAccountRepository, authority)
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(router chi.Router, endpoint *handler.LimitEndpoint) {
+		log.Println("invoke limit startup ...")
+		endpoint.BindRoutes(router)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
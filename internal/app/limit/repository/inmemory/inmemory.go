@@ -0,0 +1,32 @@
+package inmemory
+
+import (
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type InMemoryTransactionLimitRepository struct {
+	datastore []domain.TransactionLimit
+}
+
+func NewInMemoryTransactionLimitRepository(limits []domain.TransactionLimit) *InMemoryTransactionLimitRepository {
+	return &InMemoryTransactionLimitRepository{datastore: limits}
+}
+
+func (inmem *InMemoryTransactionLimitRepository) FindLimit(tier string, transactionCode string) (domain.TransactionLimit, error) {
+	for _, limit := range inmem.datastore {
+		if limit.Tier == tier && limit.TransactionCode == transactionCode {
+			return limit, nil
+		}
+	}
+	return domain.TransactionLimit{}, domain.ErrLimitNotFound
+}
+
+func (inmem *InMemoryTransactionLimitRepository) FindLimitsByTier(tier string) ([]domain.TransactionLimit, error) {
+	var limits []domain.TransactionLimit
+	for _, limit := range inmem.datastore {
+		if limit.Tier == tier {
+			limits = append(limits, limit)
+		}
+	}
+	return limits, nil
+}
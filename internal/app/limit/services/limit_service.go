@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/limit/alias"
+)
+
+type TransactionLimitServiceImp struct {
+	limitRepository       domain.TransactionLimitRepository
+	accountInformation    domain.AccountInformationService
+	transactionRepository domain.TransactionRepository
+}
+
+func NewTransactionLimitService(limitRepository domain.TransactionLimitRepository,
+	accountInformation domain.AccountInformationService,
+	transactionRepository domain.TransactionRepository) domain.TransactionLimitService {
+	return &TransactionLimitServiceImp{limitRepository: limitRepository, accountInformation: accountInformation,
+		transactionRepository: transactionRepository}
+}
+
+// CheckLimit refuses the amount and its fee when they exceed the per transaction cap of the source account's
+// tier or when they do not fit in what is left of the user's daily allowance for the transaction code.
+func (service *TransactionLimitServiceImp) CheckLimit(userID string, sourceAccount string, transactionCode string,
+	amount float64, fee float64, ctx context.Context) error {
+	if err := service.transactionRepository.LockUsage(ctx, userID, transactionCode); err != nil {
+		return err
+	}
+
+	account, err := service.accountInformation.GetAccount(sourceAccount, ctx)
+	if err != nil {
+		return err
+	}
+
+	limit, err := service.limitRepository.FindLimit(account.ProductType, transactionCode)
+	if err != nil {
+		return err
+	}
+
+	total := amount + fee
+	if total > limit.MaxPerTransaction {
+		return fmt.Errorf("%w, the maximum is %s %.0f", alias.ErrMessagePerTransactionLimitExceeded,
+			account.Currency, limit.MaxPerTransaction)
+	}
+
+	usage, err := service.usageOf(ctx, userID, limit, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if usage.RemainingCount() == 0 {
+		return fmt.Errorf("%w, %d transactions allowed per day", alias.ErrMessageDailyCountLimitExceeded,
+			limit.MaxDailyCount)
+	}
+
+	if total > usage.RemainingAmount() {
+		return fmt.Errorf("%w, the remaining allowance today is %s %.0f", alias.ErrMessageDailyAmountLimitExceeded,
+			account.Currency, usage.RemainingAmount())
+	}
+	return nil
+}
+
+func (service *TransactionLimitServiceImp) GetUsage(userID string, accountNumber string, ctx context.Context) ([]domain.LimitUsage, error) {
+	account, err := service.accountInformation.GetAccount(accountNumber, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := service.limitRepository.FindLimitsByTier(account.ProductType)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	usages := make([]domain.LimitUsage, 0, len(limits))
+	for _, limit := range limits {
+		usage, err := service.usageOf(ctx, userID, limit, now)
+		if err != nil {
+			return nil, err
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// usageOf sums the user's transactions of the limit's code created since local midnight, failed and
+// abandoned transactions did not move money and are not counted
+func (service *TransactionLimitServiceImp) usageOf(ctx context.Context, userID string, limit domain.TransactionLimit,
+	now time.Time) (domain.LimitUsage, error) {
+	usage := domain.LimitUsage{Limit: limit}
+	var err error
+	usage.UsedAmount, usage.UsedCount, err = service.transactionRepository.UsageSince(ctx, userID, limit.TransactionCode,
+		startOfDay(now))
+	return usage, err
+}
+
+func startOfDay(now time.Time) time.Time {
+	local := now.In(alias.DailyLimitLocation)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, alias.DailyLimitLocation).UTC()
+}
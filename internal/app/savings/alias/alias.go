@@ -0,0 +1,43 @@
+package alias
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+const (
+	Active  string = "Active"
+	Dormant string = "Dormant"
+	Blocked string = "Blocked"
+	Closed  string = "Closed"
+)
+
+const (
+	StatementFormatJSON string = "json"
+	StatementFormatCSV  string = "csv"
+	StatementFormatPDF  string = "pdf"
+	StatementDateLayout string = "2006-01-02"
+)
+
+var AccountStatus = map[domain.AccountStatus]string{
+	domain.AccountActive:  Active,
+	domain.AccountDormant: Dormant,
+	domain.AccountBlocked: Blocked,
+	domain.AccountClosed:  Closed,
+}
+
+var (
+	ErrMessageAccountNotFound         = errors.New("account not found")
+	ErrMessageInvalidStatementPeriod  = errors.New("from and to must be dates formatted as YYYY-MM-DD and from must not be after to")
+	ErrMessageStatementFormatNotFound = errors.New("unsupported statement format")
+)
+
+const (
+	CoreBankingTimeout          = 5 * time.Second
+	CoreBankingMaxRetries       = 3
+	CoreBankingBackoff          = 200 * time.Millisecond
+	CoreBankingBreakerThreshold = 5
+	CoreBankingBreakerCooldown  = 30 * time.Second
+)
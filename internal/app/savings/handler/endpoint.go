@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/alias"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/logger"
+	"github.com/tunaiku/mobilebanking/internal/pkg/mask"
+	"go.uber.org/zap"
+)
+
+type AccountEndpoint struct {
+	userSessionHelper         domain.UserSessionHelper
+	accountInformationService domain.AccountInformationService
+	statementService          domain.StatementService
+	userAccountRepository     domain.UserAccountRepository
+	authority                 *jwt.Authority
+}
+
+func NewAccountEndpoint(userSessionHelper domain.UserSessionHelper,
+	accountInformationService domain.AccountInformationService,
+	statementService domain.StatementService,
+	userAccountRepository domain.UserAccountRepository,
+	authority *jwt.Authority) *AccountEndpoint {
+	return &AccountEndpoint{
+		userSessionHelper:         userSessionHelper,
+		accountInformationService: accountInformationService,
+		statementService:          statementService,
+		userAccountRepository:     userAccountRepository,
+		authority:                 authority,
+	}
+}
+
+func (endpoint *AccountEndpoint) BindRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r = endpoint.authority.WrapChiRouterWithAuthorization(r)
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				next.ServeHTTP(w, r)
+			})
+		})
+		r.Get("/accounts", endpoint.HandleListAccount)
+		r.Get("/accounts/{number}", endpoint.HandleGetAccount)
+		r.Get("/accounts/{number}/inquiry", endpoint.HandleAccountInquiry)
+		r.Get("/accounts/{number}/statement", endpoint.HandleGetStatement)
+	})
+}
+
+func (endpoint *AccountEndpoint) HandleListAccount(w http.ResponseWriter, r *http.Request) {
+	userSession, err := endpoint.userSessionHelper.GetFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, &AccountHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	linkedAccounts, err := endpoint.userAccountRepository.FindAccountsByUser(userSession.ID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		render.JSON(w, r, &AccountHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	response := &ListAccountSuccess{Accounts: []AccountPayload{}}
+	for _, linked := range linkedAccounts {
+		account, err := endpoint.accountInformationService.GetAccount(linked.AccountNumber, r.Context())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			render.JSON(w, r, &AccountHandlerFailed{Message: err.Error()})
+			return
+		}
+		payload := toAccountPayload(account)
+		payload.Primary = linked.Primary
+		response.Accounts = append(response.Accounts, payload)
+	}
+
+	render.JSON(w, r, response)
+}
+
+func (endpoint *AccountEndpoint) HandleGetAccount(w http.ResponseWriter, r *http.Request) {
+	userSession, err := endpoint.userSessionHelper.GetFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, &AccountHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	accountNumber := chi.URLParam(r, "number")
+	if !endpoint.isLinked(userSession, accountNumber) {
+		w.WriteHeader(http.StatusNotFound)
+		render.JSON(w, r, &AccountHandlerFailed{Message: alias.ErrMessageAccountNotFound.Error()})
+		return
+	}
+
+	account, err := endpoint.accountInformationService.GetAccount(accountNumber, r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		render.JSON(w, r, &AccountHandlerFailed{Message: alias.ErrMessageAccountNotFound.Error()})
+		return
+	}
+
+	render.JSON(w, r, toAccountPayload(account))
+}
+
+func (endpoint *AccountEndpoint) HandleAccountInquiry(w http.ResponseWriter, r *http.Request) {
+	userSession, err := endpoint.userSessionHelper.GetFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, &AccountHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	inquiry, err := endpoint.accountInformationService.InquireAccount(chi.URLParam(r, "number"), r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		render.JSON(w, r, &AccountHandlerFailed{Message: alias.ErrMessageAccountNotFound.Error()})
+		return
+	}
+
+	holderName := inquiry.HolderName
+	if !endpoint.isLinked(userSession, inquiry.AccountNumber) {
+		holderName = mask.Name(holderName)
+	}
+
+	render.JSON(w, r, &AccountInquirySuccess{
+		AccountNumber: inquiry.AccountNumber,
+		HolderName:    holderName,
+		Status:        alias.AccountStatus[inquiry.Status],
+	})
+}
+
+func (endpoint *AccountEndpoint) HandleGetStatement(w http.ResponseWriter, r *http.Request) {
+	userSession, err := endpoint.userSessionHelper.GetFromContext(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, &AccountHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	accountNumber := chi.URLParam(r, "number")
+	if !endpoint.isLinked(userSession, accountNumber) {
+		w.WriteHeader(http.StatusNotFound)
+		render.JSON(w, r, &AccountHandlerFailed{Message: alias.ErrMessageAccountNotFound.Error()})
+		return
+	}
+
+	request := StatementRequest{}
+	if err := request.Bind(r); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, &AccountHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	summary, err := endpoint.statementService.Summarize(accountNumber, request.From, request.To, r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		render.JSON(w, r, &AccountHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	eachEntry := func(fn func(entry domain.StatementEntry) error) error {
+		return endpoint.statementService.EachEntry(summary, fn, r.Context())
+	}
+	if request.Format == alias.StatementFormatCSV {
+		if err := writeStatementCSV(w, summary, eachEntry); err != nil {
+			logger.FromContext(r.Context()).Error("failed to stream statement", zap.Error(err))
+		}
+		return
+	}
+
+	var entries []domain.StatementEntry
+	err = eachEntry(func(entry domain.StatementEntry) error {
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		render.JSON(w, r, &AccountHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	if request.Format == alias.StatementFormatPDF {
+		if err := writeStatementPDF(w, summary, entries); err != nil {
+			logger.FromContext(r.Context()).Error("failed to render statement", zap.Error(err))
+		}
+		return
+	}
+
+	render.JSON(w, r, toStatementPayload(summary, entries))
+}
+
+func (endpoint *AccountEndpoint) isLinked(userSession domain.UserSession, accountNumber string) bool {
+	linkedAccounts, err := endpoint.userAccountRepository.FindAccountsByUser(userSession.ID)
+	if err != nil {
+		return false
+	}
+	for _, linked := range linkedAccounts {
+		if linked.AccountNumber == accountNumber {
+			return true
+		}
+	}
+	return false
+}
+
+func toAccountPayload(account domain.Account) AccountPayload {
+	availableBalance, _ := account.AvailableBalance.Float64()
+	ledgerBalance, _ := account.LedgerBalance.Float64()
+	return AccountPayload{
+		AccountNumber:    account.AccountNumber,
+		HolderName:       account.HolderName,
+		AvailableBalance: availableBalance,
+		LedgerBalance:    ledgerBalance,
+		Currency:         account.Currency,
+		ProductType:      account.ProductType,
+		Status:           alias.AccountStatus[account.Status],
+	}
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/alias"
+)
+
+type AccountHandlerFailed struct {
+	Message string `json:"message"`
+}
+
+type AccountInquirySuccess struct {
+	AccountNumber string `json:"account_number"`
+	HolderName    string `json:"holder_name"`
+	Status        string `json:"status"`
+}
+
+type AccountPayload struct {
+	AccountNumber    string  `json:"account_number"`
+	HolderName       string  `json:"holder_name"`
+	AvailableBalance float64 `json:"available_balance"`
+	LedgerBalance    float64 `json:"ledger_balance"`
+	Currency         string  `json:"currency"`
+	ProductType      string  `json:"product_type"`
+	Status           string  `json:"status"`
+	Primary          bool    `json:"primary"`
+}
+
+type ListAccountSuccess struct {
+	Accounts []AccountPayload `json:"accounts"`
+}
+
+type StatementRequest struct {
+	From   time.Time
+	To     time.Time
+	Format string
+}
+
+// Bind reads the period from the query string, both dates are inclusive
+func (payload *StatementRequest) Bind(req *http.Request) error {
+	query := req.URL.Query()
+	from, err := time.Parse(alias.StatementDateLayout, query.Get("from"))
+	if err != nil {
+		return alias.ErrMessageInvalidStatementPeriod
+	}
+	to, err := time.Parse(alias.StatementDateLayout, query.Get("to"))
+	if err != nil || to.Before(from) {
+		return alias.ErrMessageInvalidStatementPeriod
+	}
+
+	payload.From = from
+	payload.To = to.AddDate(0, 0, 1)
+	payload.Format = query.Get("format")
+	switch payload.Format {
+	case "":
+		payload.Format = alias.StatementFormatJSON
+	case alias.StatementFormatJSON, alias.StatementFormatCSV, alias.StatementFormatPDF:
+	default:
+		return alias.ErrMessageStatementFormatNotFound
+	}
+	return nil
+}
+
+type StatementEntryPayload struct {
+	PostedAt    time.Time `json:"posted_at"`
+	Reference   string    `json:"reference"`
+	Description string    `json:"description"`
+	Debit       float64   `json:"debit"`
+	Credit      float64   `json:"credit"`
+	Balance     float64   `json:"balance"`
+}
+
+type StatementPayload struct {
+	AccountNumber  string                  `json:"account_number"`
+	Currency       string                  `json:"currency"`
+	From           time.Time               `json:"from"`
+	To             time.Time               `json:"to"`
+	OpeningBalance float64                 `json:"opening_balance"`
+	ClosingBalance float64                 `json:"closing_balance"`
+	Entries        []StatementEntryPayload `json:"entries"`
+}
+
+func toStatementPayload(summary domain.StatementSummary, entries []domain.StatementEntry) *StatementPayload {
+	opening, _ := summary.OpeningBalance.Float64()
+	closing, _ := summary.ClosingBalance.Float64()
+	payload := &StatementPayload{
+		AccountNumber:  summary.AccountNumber,
+		Currency:       summary.Currency,
+		From:           summary.From,
+		To:             summary.To,
+		OpeningBalance: opening,
+		ClosingBalance: closing,
+		Entries:        []StatementEntryPayload{},
+	}
+	for _, entry := range entries {
+		debit, _ := entry.Debit.Float64()
+		credit, _ := entry.Credit.Float64()
+		balance, _ := entry.Balance.Float64()
+		payload.Entries = append(payload.Entries, StatementEntryPayload{
+			PostedAt:    entry.PostedAt,
+			Reference:   entry.Reference,
+			Description: entry.Description,
+			Debit:       debit,
+			Credit:      credit,
+			Balance:     balance,
+		})
+	}
+	return payload
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/alias"
+)
+
+// csvFlushEvery bounds how many rows are buffered before they are pushed to the client
+const csvFlushEvery = 100
+
+func amountText(amount *big.Float) string {
+	if amount == nil {
+		return ""
+	}
+	return amount.Text('f', 2)
+}
+
+func writeStatementCSV(w http.ResponseWriter, summary domain.StatementSummary,
+	eachEntry func(fn func(entry domain.StatementEntry) error) error) error {
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", statementFileName(summary, alias.StatementFormatCSV)))
+
+	flusher, _ := w.(http.Flusher)
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"account_number", summary.AccountNumber})
+	_ = writer.Write([]string{"currency", summary.Currency})
+	_ = writer.Write([]string{"opening_balance", amountText(summary.OpeningBalance)})
+	_ = writer.Write([]string{"posted_at", "reference", "description", "debit", "credit", "balance"})
+
+	rows := 0
+	err := eachEntry(func(entry domain.StatementEntry) error {
+		rows++
+		if err := writer.Write([]string{
+			entry.PostedAt.Format(time.RFC3339),
+			entry.Reference,
+			entry.Description,
+			amountText(entry.Debit),
+			amountText(entry.Credit),
+			amountText(entry.Balance),
+		}); err != nil {
+			return err
+		}
+		if rows%csvFlushEvery == 0 {
+			writer.Flush()
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return writer.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	_ = writer.Write([]string{"closing_balance", amountText(summary.ClosingBalance)})
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeStatementPDF(w http.ResponseWriter, summary domain.StatementSummary, entries []domain.StatementEntry) error {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.AddPage()
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.Cell(0, 10, "Account Statement")
+	pdf.Ln(10)
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.Cell(0, 6, fmt.Sprintf("Account: %s (%s)", summary.AccountNumber, summary.Currency))
+	pdf.Ln(6)
+	pdf.Cell(0, 6, fmt.Sprintf("Period: %s - %s", summary.From.Format(alias.StatementDateLayout),
+		summary.To.Format(alias.StatementDateLayout)))
+	pdf.Ln(6)
+	pdf.Cell(0, 6, "Opening balance: "+amountText(summary.OpeningBalance))
+	pdf.Ln(10)
+
+	widths := []float64{40, 75, 70, 30, 30, 32}
+	pdf.SetFont("Helvetica", "B", 9)
+	for i, title := range []string{"Date", "Reference", "Description", "Debit", "Credit", "Balance"} {
+		pdf.CellFormat(widths[i], 7, title, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 8)
+	for _, entry := range entries {
+		pdf.CellFormat(widths[0], 6, entry.PostedAt.Format("2006-01-02 15:04"), "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[1], 6, entry.Reference, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[2], 6, entry.Description, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(widths[3], 6, amountText(entry.Debit), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[4], 6, amountText(entry.Credit), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(widths[5], 6, amountText(entry.Balance), "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.Cell(0, 6, "Closing balance: "+amountText(summary.ClosingBalance))
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", statementFileName(summary, alias.StatementFormatPDF)))
+	return pdf.Output(w)
+}
+
+func statementFileName(summary domain.StatementSummary, format string) string {
+	return fmt.Sprintf("statement-%s-%s-%s.%s", summary.AccountNumber,
+		summary.From.Format(alias.StatementDateLayout), summary.To.Format(alias.StatementDateLayout), format)
+}
@@ -0,0 +1,111 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/csv"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/handler"
+)
+
+type stubUserSessionHelper struct {
+}
+
+func (stub *stubUserSessionHelper) GetFromContext(ctx context.Context) (domain.UserSession, error) {
+	return domain.UserSession{User: &domain.User{ID: "user-1"}}, nil
+}
+
+type stubUserAccountRepository struct {
+	domain.UserAccountRepository
+}
+
+func (stub *stubUserAccountRepository) FindAccountsByUser(userID string) ([]domain.UserAccount, error) {
+	return []domain.UserAccount{{UserID: userID, AccountNumber: "10001", Primary: true}}, nil
+}
+
+type stubStatementService struct {
+	entries []domain.StatementEntry
+}
+
+func (stub *stubStatementService) Summarize(accountNumber string, from time.Time, to time.Time,
+	ctx context.Context) (domain.StatementSummary, error) {
+	return domain.StatementSummary{AccountNumber: accountNumber, Currency: "IDR", From: from, To: to,
+		OpeningBalance: big.NewFloat(100000), ClosingBalance: big.NewFloat(47500)}, nil
+}
+
+func (stub *stubStatementService) EachEntry(summary domain.StatementSummary, fn func(entry domain.StatementEntry) error,
+	ctx context.Context) error {
+	for _, entry := range stub.entries {
+		if err := fn(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getStatement(t *testing.T, format string) *httptest.ResponseRecorder {
+	statementService := &stubStatementService{entries: []domain.StatementEntry{
+		{PostedAt: time.Date(2026, time.January, 10, 9, 0, 0, 0, time.UTC), Reference: "trx-1", Description: "transfer to 10002",
+			Debit: big.NewFloat(50000), Credit: new(big.Float), Balance: big.NewFloat(50000)},
+		{PostedAt: time.Date(2026, time.January, 10, 9, 0, 0, 0, time.UTC), Reference: "trx-1", Description: "transfer fee",
+			Debit: big.NewFloat(2500), Credit: new(big.Float), Balance: big.NewFloat(47500)},
+	}}
+	endpoint := handler.NewAccountEndpoint(&stubUserSessionHelper{}, nil, statementService, &stubUserAccountRepository{}, nil)
+	router := chi.NewRouter()
+	router.Get("/accounts/{number}/statement", endpoint.HandleGetStatement)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet,
+		"/accounts/10001/statement?from=2026-01-01&to=2026-01-31&format="+format, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status should be 200 but was %d: %s", recorder.Code, recorder.Body.String())
+	}
+	return recorder
+}
+
+func TestHandleGetStatement_Should_StreamTheEntriesBetweenTheBalances_When_TheFormatIsCsv(t *testing.T) {
+	recorder := getStatement(t, "csv")
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "text/csv" {
+		t.Fatalf("content type should be text/csv but was %s", contentType)
+	}
+	if disposition := recorder.Header().Get("Content-Disposition"); !strings.Contains(disposition, "statement-10001-2026-01-01-2026-02-01.csv") {
+		t.Fatalf("unexpected content disposition %s", disposition)
+	}
+	reader := csv.NewReader(recorder.Body)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 7 {
+		t.Fatalf("expected the header, 2 entries and the closing balance, got %v", rows)
+	}
+	if rows[2][1] != "100000.00" || rows[3][0] != "posted_at" {
+		t.Fatalf("the opening balance and the column titles should lead, got %v", rows[:4])
+	}
+	if strings.Join(rows[5], ",") != "2026-01-10T09:00:00Z,trx-1,transfer fee,2500.00,0.00,47500.00" {
+		t.Fatalf("unexpected entry %v", rows[5])
+	}
+	if rows[6][0] != "closing_balance" || rows[6][1] != "47500.00" {
+		t.Fatalf("the closing balance should end the statement, got %v", rows[6])
+	}
+}
+
+func TestHandleGetStatement_Should_RenderAPdfDocument_When_TheFormatIsPdf(t *testing.T) {
+	recorder := getStatement(t, "pdf")
+
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/pdf" {
+		t.Fatalf("content type should be application/pdf but was %s", contentType)
+	}
+	if !strings.HasPrefix(recorder.Body.String(), "%PDF-") {
+		t.Fatal("the body should be a pdf document")
+	}
+}
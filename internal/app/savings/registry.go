@@ -1,23 +1,75 @@
 package savings
 
 import (
+	"log"
+
+	"github.com/go-chi/chi"
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/handler"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/service/corebanking"
 	"github.com/tunaiku/mobilebanking/internal/app/savings/service/fake"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/service/statement"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/health"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
 	"go.uber.org/dig"
+	"go.uber.org/zap"
 )
 
 func Register(container *dig.Container) {
-	container.Provide(func() domain.AccountInformationService {
+	container.Provide(func(cfg *config.Config) *corebanking.Client {
+		return corebanking.NewClient(corebanking.Options{
+			BaseURL:          cfg.CoreBanking.URL,
+			Timeout:          alias.CoreBankingTimeout,
+			MaxRetries:       alias.CoreBankingMaxRetries,
+			Backoff:          alias.CoreBankingBackoff,
+			BreakerThreshold: alias.CoreBankingBreakerThreshold,
+			BreakerCooldown:  alias.CoreBankingBreakerCooldown,
+		})
+	})
+	container.Provide(func(cfg *config.Config, client *corebanking.Client) domain.AccountInformationService {
+		if cfg.CoreBanking.Mode == config.CoreBankingModeHTTP {
+			return corebanking.NewAccountInformationService(client)
+		}
 		return fake.NewFakeAccountInformationService()
 	})
-	container.Provide(func() domain.TransactionInformationService {
+	container.Provide(func(cfg *config.Config, client *corebanking.Client) domain.TransactionInformationService {
+		if cfg.CoreBanking.Mode == config.CoreBankingModeHTTP {
+			return corebanking.NewTransactionInformationService(client)
+		}
 		return fake.NewFakeTransactionInformationService()
 	})
-	container.Provide(func() domain.TransactionService {
-		return fake.NewFakeTransactionService()
+	container.Provide(func(cfg *config.Config, client *corebanking.Client, logger *zap.Logger) domain.TransactionService {
+		if cfg.CoreBanking.Mode == config.CoreBankingModeHTTP {
+			return corebanking.NewAccountTransactionService(client)
+		}
+		return fake.NewFakeTransactionService(logger)
+	})
+	container.Provide(func(accountInformationService domain.AccountInformationService,
+		transactionInformationService domain.TransactionInformationService,
+		transactionRepository domain.TransactionRepository) domain.StatementService {
+		return statement.NewStatementServiceImpl(accountInformationService, transactionInformationService,
+			transactionRepository)
+	})
+	container.Provide(func(userSessionHelper domain.UserSessionHelper,
+		accountInformationService domain.AccountInformationService,
+		statementService domain.StatementService,
+		userAccountRepository domain.UserAccountRepository,
+		authority *jwt.Authority) *handler.AccountEndpoint {
+		return handler.NewAccountEndpoint(userSessionHelper, accountInformationService, statementService,
+			userAccountRepository, authority)
 	})
 }
 
 func Invoke(container *dig.Container) {
-
+	err := container.Invoke(func(router chi.Router, endpoint *handler.AccountEndpoint,
+		accountInformationService domain.AccountInformationService, healthRegistry *health.Registry) {
+		log.Println("invoke savings startup ...")
+		endpoint.BindRoutes(router)
+		healthRegistry.RegisterChecker("savings", accountInformationService)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 }
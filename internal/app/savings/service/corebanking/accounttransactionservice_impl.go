@@ -0,0 +1,27 @@
+package corebanking
+
+import (
+	"context"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+// AccountTransactionService books transfers on the core, it is not retried since the core
+// deduplicates on the idempotency key and a lost response is reconciled from the postings instead.
+type AccountTransactionService struct {
+	client *Client
+}
+
+func NewAccountTransactionService(client *Client) *AccountTransactionService {
+	return &AccountTransactionService{client: client}
+}
+
+// CreateTransaction reports a posting the core refused as a *domain.TransactionRejectedError, any other
+// error may succeed when the posting is sent again
+func (impl *AccountTransactionService) CreateTransaction(transactionCreation domain.TransactionCreation, ctx context.Context) error {
+	err := impl.client.post(ctx, "/transfers", transactionCreation.Reference, newTransferPayload(transactionCreation), nil)
+	if err != nil && isRejection(err) {
+		return &domain.TransactionRejectedError{Reason: err.Error()}
+	}
+	return err
+}
@@ -0,0 +1,205 @@
+package corebanking
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/breaker"
+	"github.com/tunaiku/mobilebanking/internal/pkg/tracing"
+)
+
+const (
+	errorCodeAccountNotFound         = "ACCOUNT_NOT_FOUND"
+	errorCodeTransactionCodeNotFound = "TRANSACTION_CODE_NOT_FOUND"
+	idempotencyKeyHeader             = "Idempotency-Key"
+	healthPath                       = "/health"
+	spanName                         = "corebanking"
+)
+
+type Options struct {
+	BaseURL string
+	Timeout time.Duration
+	// MaxRetries is how many times an idempotent call is retried after a transient failure
+	MaxRetries int
+	// Backoff is the wait before the first retry, it doubles on every following one
+	Backoff          time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// Client calls the core banking REST API, it is shared by the adapters of this package so they
+// trip the same circuit breaker.
+type Client struct {
+	options    Options
+	httpClient *http.Client
+	breaker    *breaker.Breaker
+}
+
+func NewClient(options Options) *Client {
+	return &Client{
+		options:    options,
+		httpClient: &http.Client{Timeout: options.Timeout, Transport: tracing.NewTransport(spanName, nil)},
+		breaker:    breaker.New(options.BreakerThreshold, options.BreakerCooldown),
+	}
+}
+
+type remoteError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type unexpectedStatusError struct {
+	status int
+	remote remoteError
+}
+
+func (err *unexpectedStatusError) Error() string {
+	return fmt.Sprintf("core banking responded %d: %s %s", err.status, err.remote.Code, err.remote.Message)
+}
+
+// get reads a resource, transient failures are retried with an exponential backoff until ctx is done
+func (client *Client) get(ctx context.Context, path string, out interface{}) error {
+	backoff := client.options.Backoff
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = client.do(ctx, http.MethodGet, path, nil, nil, out)
+		if !isTransient(err) || attempt >= client.options.MaxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post creates a resource once, the idempotency key lets the core recognize a request it already processed
+func (client *Client) post(ctx context.Context, path string, idempotencyKey string, in interface{}, out interface{}) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return client.do(ctx, http.MethodPost, path, body, map[string]string{idempotencyKeyHeader: idempotencyKey}, out)
+}
+
+func (client *Client) do(ctx context.Context, method string, path string, body []byte, headers map[string]string, out interface{}) error {
+	if err := client.breaker.Allow(); err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, client.options.BaseURL+path, reader)
+	if err != nil {
+		client.breaker.Success()
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		// a call given up by its caller says nothing about the core, it must not open the breaker for everyone
+		if ctx.Err() != nil {
+			client.breaker.Abandon()
+			return ctx.Err()
+		}
+		client.breaker.Failure()
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		client.breaker.Failure()
+		return readError(resp)
+	}
+	client.breaker.Success()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return readError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// isRejection tells whether the core refused the request itself, a client error other than a timeout,
+// a conflict or a throttling which may succeed when sent again
+func isRejection(err error) bool {
+	if err == domain.ErrAccountNotFound {
+		return true
+	}
+	var statusErr *unexpectedStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	switch statusErr.status {
+	case http.StatusRequestTimeout, http.StatusConflict, http.StatusTooManyRequests:
+		return false
+	}
+	return statusErr.status >= http.StatusBadRequest && statusErr.status < http.StatusInternalServerError
+}
+
+func readError(resp *http.Response) error {
+	err := &unexpectedStatusError{status: resp.StatusCode}
+	_ = json.NewDecoder(resp.Body).Decode(&err.remote)
+
+	switch err.remote.Code {
+	case errorCodeAccountNotFound:
+		return domain.ErrAccountNotFound
+	case errorCodeTransactionCodeNotFound:
+		return domain.ErrTransactionDetailNotFound
+	}
+	return err
+}
+
+// HealthCheck probes the core without going through the retries, an open breaker is reported as is
+// and the probe outcome does not count toward tripping it
+func (client *Client) HealthCheck(ctx context.Context) error {
+	if client.breaker.Open() {
+		return breaker.ErrOpen
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.options.BaseURL+healthPath, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return readError(resp)
+	}
+	return nil
+}
+
+// isTransient tells whether retrying the call may succeed, the breaker being open is not worth retrying
+func isTransient(err error) bool {
+	if err == nil || err == breaker.ErrOpen {
+		return false
+	}
+	if statusErr, ok := err.(*unexpectedStatusError); ok {
+		return statusErr.status >= http.StatusInternalServerError
+	}
+	_, isNetworkErr := err.(*url.Error)
+	return isNetworkErr
+}
@@ -0,0 +1,254 @@
+package corebanking_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/service/corebanking"
+	"github.com/tunaiku/mobilebanking/internal/pkg/breaker"
+)
+
+func newClient(server *httptest.Server) *corebanking.Client {
+	return corebanking.NewClient(corebanking.Options{
+		BaseURL:          server.URL,
+		Timeout:          time.Second,
+		MaxRetries:       2,
+		Backoff:          time.Millisecond,
+		BreakerThreshold: 3,
+		BreakerCooldown:  time.Minute,
+	})
+}
+
+func TestGetAccount_Should_MapTheRemoteAccount_When_TheCoreRespondsOk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accounts/10001" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"account_number":"10001","holder_name":"John Doe","available_balance":10000000.50,` +
+			`"ledger_balance":10500000,"currency":"IDR","product_type":"savings","status":"ACTIVE"}`))
+	}))
+	defer server.Close()
+
+	account, err := corebanking.NewAccountInformationService(newClient(server)).GetAccount("10001", context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.HolderName != "John Doe" || account.Status != domain.AccountActive {
+		t.Fatalf("unexpected account %+v", account)
+	}
+	if account.AvailableBalance.Cmp(big.NewFloat(10000000.50)) != 0 {
+		t.Fatalf("available balance should be 10000000.50 but was %s", account.AvailableBalance.String())
+	}
+}
+
+func TestGetAccount_Should_ReturnErrAccountNotFound_When_TheCoreDoesNotKnowTheAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"ACCOUNT_NOT_FOUND","message":"no such account"}`))
+	}))
+	defer server.Close()
+
+	service := corebanking.NewAccountInformationService(newClient(server))
+	if _, err := service.GetAccount("99999", context.Background()); err != domain.ErrAccountNotFound {
+		t.Fatalf("err should be `domain.ErrAccountNotFound` but was %v", err)
+	}
+	if service.IsAccountExists("99999", context.Background()) {
+		t.Fatal("account should not exist")
+	}
+}
+
+func TestFindTransactionDetailByCode_Should_ReturnErrTransactionDetailNotFound_When_TheCodeIsUnknown(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"code":"TRANSACTION_CODE_NOT_FOUND"}`))
+	}))
+	defer server.Close()
+
+	_, err := corebanking.NewTransactionInformationService(newClient(server)).FindTransactionDetailByCode("T009", context.Background())
+	if err != domain.ErrTransactionDetailNotFound {
+		t.Fatalf("err should be `domain.ErrTransactionDetailNotFound` but was %v", err)
+	}
+}
+
+func TestFindTransactionDetailByCode_Should_Retry_When_TheCoreIsTemporarilyUnavailable(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"code":"T002","minimum_amount":3000,"fee":{"type":"FLAT","flat":6500}}`))
+	}))
+	defer server.Close()
+
+	detail, err := corebanking.NewTransactionInformationService(newClient(server)).FindTransactionDetailByCode("T002", context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Fee.Type != domain.FlatFee || detail.Fee.Flat != 6500 {
+		t.Fatalf("unexpected fee rule %+v", detail.Fee)
+	}
+	if calls != 2 {
+		t.Fatalf("core should be called twice but was called %d times", calls)
+	}
+}
+
+func TestFindTransactionDetailByCode_Should_StopRetrying_When_TheContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := corebanking.NewClient(corebanking.Options{
+		BaseURL:          server.URL,
+		Timeout:          time.Second,
+		MaxRetries:       2,
+		Backoff:          time.Hour,
+		BreakerThreshold: 3,
+		BreakerCooldown:  time.Minute,
+	})
+	_, err := corebanking.NewTransactionInformationService(client).FindTransactionDetailByCode("T002", ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err should be `context.Canceled` but was %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("core should be called once but was called %d times", calls)
+	}
+}
+
+func TestCreateTransaction_Should_NotRetry_When_TheCoreFails(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.Header.Get("Idempotency-Key") != "trx-1" {
+			t.Fatalf("idempotency key should be the reference but was %q", r.Header.Get("Idempotency-Key"))
+		}
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := corebanking.NewAccountTransactionService(newClient(server)).CreateTransaction(domain.TransactionCreation{
+		SourceAccount:      "10001",
+		DestinationAccount: "10002",
+		TransactionCode:    "T001",
+		Amount:             big.NewFloat(50000),
+		Currency:           "IDR",
+		Reference:          "trx-1",
+	}, context.Background())
+	if err == nil {
+		t.Fatal("err should not be nil")
+	}
+	if calls != 1 {
+		t.Fatalf("core should be called once but was called %d times", calls)
+	}
+}
+
+func TestCreateTransaction_Should_ReportARejection_When_TheCoreRefusesThePosting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"code":"INSUFFICIENT_FUNDS","message":"insufficient funds"}`))
+	}))
+	defer server.Close()
+
+	err := corebanking.NewAccountTransactionService(newClient(server)).CreateTransaction(domain.TransactionCreation{
+		Amount:    big.NewFloat(50000),
+		Reference: "trx-1",
+	}, context.Background())
+	var rejected *domain.TransactionRejectedError
+	if !errors.As(err, &rejected) {
+		t.Fatalf("err should be a `*domain.TransactionRejectedError` but was %v", err)
+	}
+}
+
+func TestCreateTransaction_Should_NotReportARejection_When_TheCoreFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := corebanking.NewAccountTransactionService(newClient(server)).CreateTransaction(domain.TransactionCreation{
+		Amount:    big.NewFloat(50000),
+		Reference: "trx-1",
+	}, context.Background())
+	var rejected *domain.TransactionRejectedError
+	if err == nil || errors.As(err, &rejected) {
+		t.Fatalf("err should be a transient failure but was %v", err)
+	}
+}
+
+func TestClient_Should_StopCallingTheCore_When_TheBreakerIsOpen(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	service := corebanking.NewAccountInformationService(newClient(server))
+	service.GetAccount("10001", context.Background())
+	if _, err := service.GetAccount("10001", context.Background()); err != breaker.ErrOpen {
+		t.Fatalf("err should be `breaker.ErrOpen` but was %v", err)
+	}
+	if calls != 3 {
+		t.Fatalf("core should be called 3 times before the breaker opens but was called %d times", calls)
+	}
+}
+
+func TestClient_Should_KeepTheBreakerClosed_When_TheCallerGivesUp(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	service := corebanking.NewAccountTransactionService(newClient(server))
+	for i := 0; i < 4; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		err := service.CreateTransaction(domain.TransactionCreation{Amount: big.NewFloat(3000)}, ctx)
+		cancel()
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err should be `context.DeadlineExceeded` but was %v", err)
+		}
+	}
+	if calls != 4 {
+		t.Fatalf("core should be called 4 times but was called %d times", calls)
+	}
+}
+
+func TestHealthCheck_Should_ReportTheOpenBreaker_When_TheCoreKeepsFailing(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	client := newClient(server)
+
+	if err := client.HealthCheck(context.Background()); err == nil {
+		t.Fatal("expected the failing core to be reported")
+	}
+	for i := 0; i < 3; i++ {
+		corebanking.NewAccountInformationService(client).GetAccount("10001", context.Background())
+	}
+	callsBeforeProbe := atomic.LoadInt32(&calls)
+	if err := client.HealthCheck(context.Background()); err != breaker.ErrOpen {
+		t.Fatalf("expected breaker.ErrOpen, got %v", err)
+	}
+	if atomic.LoadInt32(&calls) != callsBeforeProbe {
+		t.Fatal("the probe must not call the core while the breaker is open")
+	}
+}
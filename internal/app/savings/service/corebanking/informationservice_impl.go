@@ -0,0 +1,95 @@
+package corebanking
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+const queryTimeLayout = time.RFC3339
+
+type AccountInformationService struct {
+	client *Client
+}
+
+func NewAccountInformationService(client *Client) *AccountInformationService {
+	return &AccountInformationService{client: client}
+}
+
+func (impl *AccountInformationService) HealthCheck(ctx context.Context) error {
+	return impl.client.HealthCheck(ctx)
+}
+
+func (impl *AccountInformationService) IsAccountExists(accountNumber string, ctx context.Context) bool {
+	_, err := impl.GetAccount(accountNumber, ctx)
+	return err == nil
+}
+
+func (impl *AccountInformationService) GetTransactionPrivileges(accountNumber string, ctx context.Context) (domain.TransactionPrivileges, error) {
+	var payload privilegesPayload
+	if err := impl.client.get(ctx, "/accounts/"+url.PathEscape(accountNumber)+"/privileges", &payload); err != nil {
+		return domain.TransactionPrivileges{}, err
+	}
+	return domain.TransactionPrivileges{Codes: payload.TransactionCodes}, nil
+}
+
+func (impl *AccountInformationService) InquireAccount(accountNumber string, ctx context.Context) (domain.AccountInquiry, error) {
+	account, err := impl.GetAccount(accountNumber, ctx)
+	if err != nil {
+		return domain.AccountInquiry{}, err
+	}
+	return domain.AccountInquiry{
+		AccountNumber: account.AccountNumber,
+		HolderName:    account.HolderName,
+		Status:        account.Status,
+	}, nil
+}
+
+func (impl *AccountInformationService) GetAccount(accountNumber string, ctx context.Context) (domain.Account, error) {
+	var payload accountPayload
+	if err := impl.client.get(ctx, "/accounts/"+url.PathEscape(accountNumber), &payload); err != nil {
+		return domain.Account{}, err
+	}
+	return payload.toAccount(), nil
+}
+
+type TransactionInformationService struct {
+	client *Client
+}
+
+func NewTransactionInformationService(client *Client) *TransactionInformationService {
+	return &TransactionInformationService{client: client}
+}
+
+func (impl *TransactionInformationService) FindTransactionDetailByCode(code string, ctx context.Context) (domain.TransactionDetail, error) {
+	var payload transactionCodePayload
+	if err := impl.client.get(ctx, "/transaction-codes/"+url.PathEscape(code), &payload); err != nil {
+		return domain.TransactionDetail{}, err
+	}
+	return payload.toTransactionDetail(), nil
+}
+
+func (impl *TransactionInformationService) FindPostings(accountNumber string, from time.Time, to time.Time, ctx context.Context) ([]domain.AccountPosting, error) {
+	query := url.Values{}
+	query.Set("from", from.UTC().Format(queryTimeLayout))
+	query.Set("to", to.UTC().Format(queryTimeLayout))
+
+	var payload postingsPayload
+	if err := impl.client.get(ctx, "/accounts/"+url.PathEscape(accountNumber)+"/postings?"+query.Encode(), &payload); err != nil {
+		return nil, err
+	}
+
+	postings := make([]domain.AccountPosting, 0, len(payload.Postings))
+	for _, posting := range payload.Postings {
+		postings = append(postings, domain.AccountPosting{
+			AccountNumber: accountNumber,
+			Reference:     posting.Reference,
+			Description:   posting.Description,
+			Amount:        toBigFloat(posting.Amount),
+			PostedAt:      posting.PostedAt,
+		})
+	}
+	return postings, nil
+}
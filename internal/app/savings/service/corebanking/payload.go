@@ -0,0 +1,142 @@
+package corebanking
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+var accountStatuses = map[string]domain.AccountStatus{
+	"ACTIVE":  domain.AccountActive,
+	"DORMANT": domain.AccountDormant,
+	"BLOCKED": domain.AccountBlocked,
+	"CLOSED":  domain.AccountClosed,
+}
+
+var feeTypes = map[string]domain.FeeType{
+	"FLAT":       domain.FlatFee,
+	"PERCENTAGE": domain.PercentageFee,
+	"TIERED":     domain.TieredFee,
+}
+
+type accountPayload struct {
+	AccountNumber    string      `json:"account_number"`
+	HolderName       string      `json:"holder_name"`
+	AvailableBalance json.Number `json:"available_balance"`
+	LedgerBalance    json.Number `json:"ledger_balance"`
+	Currency         string      `json:"currency"`
+	ProductType      string      `json:"product_type"`
+	Status           string      `json:"status"`
+}
+
+func (payload accountPayload) toAccount() domain.Account {
+	return domain.Account{
+		AccountNumber:    payload.AccountNumber,
+		HolderName:       payload.HolderName,
+		AvailableBalance: toBigFloat(payload.AvailableBalance),
+		LedgerBalance:    toBigFloat(payload.LedgerBalance),
+		Currency:         payload.Currency,
+		ProductType:      payload.ProductType,
+		Status:           accountStatuses[payload.Status],
+	}
+}
+
+type privilegesPayload struct {
+	TransactionCodes []string `json:"transaction_codes"`
+}
+
+type feeTierPayload struct {
+	UpTo float64 `json:"up_to"`
+	Fee  float64 `json:"fee"`
+}
+
+type feeRulePayload struct {
+	Type                  string           `json:"type"`
+	Flat                  float64          `json:"flat"`
+	Percentage            float64          `json:"percentage"`
+	MinimumFee            float64          `json:"minimum_fee"`
+	MaximumFee            float64          `json:"maximum_fee"`
+	Tiers                 []feeTierPayload `json:"tiers"`
+	FreeTransfersPerMonth int              `json:"free_transfers_per_month"`
+}
+
+type transactionCodePayload struct {
+	Code          string         `json:"code"`
+	MinimumAmount json.Number    `json:"minimum_amount"`
+	Fee           feeRulePayload `json:"fee"`
+}
+
+func (payload transactionCodePayload) toTransactionDetail() domain.TransactionDetail {
+	tiers := make([]domain.FeeTier, 0, len(payload.Fee.Tiers))
+	for _, tier := range payload.Fee.Tiers {
+		tiers = append(tiers, domain.FeeTier{UpTo: tier.UpTo, Fee: tier.Fee})
+	}
+	return domain.TransactionDetail{
+		Code:          payload.Code,
+		MinimumAmount: toBigFloat(payload.MinimumAmount),
+		Fee: domain.FeeRule{
+			Type:                  feeTypes[payload.Fee.Type],
+			Flat:                  payload.Fee.Flat,
+			Percentage:            payload.Fee.Percentage,
+			MinimumFee:            payload.Fee.MinimumFee,
+			MaximumFee:            payload.Fee.MaximumFee,
+			Tiers:                 tiers,
+			FreeTransfersPerMonth: payload.Fee.FreeTransfersPerMonth,
+		},
+	}
+}
+
+type postingPayload struct {
+	Reference   string      `json:"reference"`
+	Description string      `json:"description"`
+	Amount      json.Number `json:"amount"`
+	PostedAt    time.Time   `json:"posted_at"`
+}
+
+type postingsPayload struct {
+	Postings []postingPayload `json:"postings"`
+}
+
+type transferPayload struct {
+	SourceAccount      string     `json:"source_account"`
+	DestinationAccount string     `json:"destination_account"`
+	TransactionCode    string     `json:"transaction_code"`
+	Amount             string     `json:"amount"`
+	Fee                string     `json:"fee,omitempty"`
+	Currency           string     `json:"currency"`
+	TransactionDate    *time.Time `json:"transaction_date,omitempty"`
+	Reference          string     `json:"reference"`
+}
+
+func newTransferPayload(creation domain.TransactionCreation) transferPayload {
+	payload := transferPayload{
+		SourceAccount:      creation.SourceAccount,
+		DestinationAccount: creation.DestinationAccount,
+		TransactionCode:    creation.TransactionCode,
+		Amount:             fromBigFloat(creation.Amount),
+		Currency:           creation.Currency,
+		TransactionDate:    creation.TransactionDate,
+		Reference:          creation.Reference,
+	}
+	if creation.Fee != nil && creation.Fee.Sign() != 0 {
+		payload.Fee = fromBigFloat(creation.Fee)
+	}
+	return payload
+}
+
+func toBigFloat(number json.Number) *big.Float {
+	value, ok := new(big.Float).SetString(number.String())
+	if !ok {
+		return new(big.Float)
+	}
+	return value
+}
+
+func fromBigFloat(value *big.Float) string {
+	if value == nil {
+		return "0"
+	}
+	return value.Text('f', -1)
+}
@@ -1,19 +1,24 @@
 package fake
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"go.uber.org/zap"
 )
 
 type FakeAccountTransactionService struct {
+	logger *zap.Logger
 }
 
-func NewFakeTransactionService() *FakeAccountTransactionService {
-	return &FakeAccountTransactionService{}
+func NewFakeTransactionService(logger *zap.Logger) *FakeAccountTransactionService {
+	return &FakeAccountTransactionService{logger: logger}
 }
 
-func (fake *FakeAccountTransactionService) CreateTransaction(transactionCreation domain.TransactionCreation) error {
-	fmt.Println("creating transaction...")
+func (fake *FakeAccountTransactionService) CreateTransaction(transactionCreation domain.TransactionCreation, ctx context.Context) error {
+	fake.logger.Info("booking transfer", zap.String("reference", transactionCreation.Reference),
+		zap.String("source_account", transactionCreation.SourceAccount),
+		zap.String("destination_account", transactionCreation.DestinationAccount))
+	fakeLedger.record(transactionCreation)
 	return nil
 }
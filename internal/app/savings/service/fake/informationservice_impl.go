@@ -1,7 +1,9 @@
 package fake
 
 import (
+	"context"
 	"math/big"
+	"time"
 
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
 )
@@ -14,12 +16,51 @@ var trxDetails = map[string]domain.TransactionDetail{
 	"T002": {
 		Code:          "T002",
 		MinimumAmount: big.NewFloat(3000.0),
+		Fee: domain.FeeRule{
+			Type: domain.TieredFee,
+			Tiers: []domain.FeeTier{
+				{UpTo: 1000000, Fee: 2500},
+				{Fee: 6500},
+			},
+			FreeTransfersPerMonth: 2,
+		},
 	},
 }
 
 var accountPrivileges = map[string][]string{
 	"10001": {"T001", "T002"},
 	"10002": {"T001"},
+	"20001": {"T001"},
+}
+
+var accounts = map[string]domain.Account{
+	"10001": {
+		AccountNumber:    "10001",
+		HolderName:       "John Doe",
+		AvailableBalance: big.NewFloat(10000000),
+		LedgerBalance:    big.NewFloat(10500000),
+		Currency:         "IDR",
+		ProductType:      "savings",
+		Status:           domain.AccountActive,
+	},
+	"10002": {
+		AccountNumber:    "10002",
+		HolderName:       "Jane Doe",
+		AvailableBalance: big.NewFloat(5000000),
+		LedgerBalance:    big.NewFloat(5000000),
+		Currency:         "IDR",
+		ProductType:      "savings",
+		Status:           domain.AccountActive,
+	},
+	"20001": {
+		AccountNumber:    "20001",
+		HolderName:       "John Doe & Jane Doe",
+		AvailableBalance: big.NewFloat(25000000),
+		LedgerBalance:    big.NewFloat(25000000),
+		Currency:         "IDR",
+		ProductType:      "current",
+		Status:           domain.AccountActive,
+	},
 }
 
 type FakeAccountInformationService struct {
@@ -29,17 +70,41 @@ func NewFakeAccountInformationService() *FakeAccountInformationService {
 	return &FakeAccountInformationService{}
 }
 
-func (impl *FakeAccountInformationService) IsAccountExists(accountNumber string) bool {
+// HealthCheck always succeeds, the fake core lives in process
+func (impl *FakeAccountInformationService) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+func (impl *FakeAccountInformationService) IsAccountExists(accountNumber string, ctx context.Context) bool {
 	return accountPrivileges[accountNumber] != nil
 }
 
-func (impl *FakeAccountInformationService) GetTransactionPrivileges(accountNumber string) (domain.TransactionPrivileges, error) {
-	if !impl.IsAccountExists(accountNumber) {
+func (impl *FakeAccountInformationService) GetTransactionPrivileges(accountNumber string, ctx context.Context) (domain.TransactionPrivileges, error) {
+	if !impl.IsAccountExists(accountNumber, ctx) {
 		return domain.TransactionPrivileges{}, domain.ErrAccountNotFound
 	}
 	return domain.TransactionPrivileges{Codes: accountPrivileges[accountNumber]}, nil
 }
 
+func (impl *FakeAccountInformationService) InquireAccount(accountNumber string, ctx context.Context) (domain.AccountInquiry, error) {
+	if !impl.IsAccountExists(accountNumber, ctx) {
+		return domain.AccountInquiry{}, domain.ErrAccountNotFound
+	}
+	account := accounts[accountNumber]
+	return domain.AccountInquiry{
+		AccountNumber: account.AccountNumber,
+		HolderName:    account.HolderName,
+		Status:        account.Status,
+	}, nil
+}
+
+func (impl *FakeAccountInformationService) GetAccount(accountNumber string, ctx context.Context) (domain.Account, error) {
+	if !impl.IsAccountExists(accountNumber, ctx) {
+		return domain.Account{}, domain.ErrAccountNotFound
+	}
+	return accounts[accountNumber], nil
+}
+
 type FakeTransactionInformationService struct {
 }
 
@@ -47,10 +112,14 @@ func NewFakeTransactionInformationService() *FakeTransactionInformationService {
 	return new(FakeTransactionInformationService)
 }
 
-func (impl *FakeTransactionInformationService) FindTransactionDetailByCode(code string) (domain.TransactionDetail, error) {
-	trx := trxDetails[code]
-	if (trx == domain.TransactionDetail{}) {
+func (impl *FakeTransactionInformationService) FindTransactionDetailByCode(code string, ctx context.Context) (domain.TransactionDetail, error) {
+	trx, ok := trxDetails[code]
+	if !ok {
 		return domain.TransactionDetail{}, domain.ErrTransactionDetailNotFound
 	}
 	return trx, nil
 }
+
+func (impl *FakeTransactionInformationService) FindPostings(accountNumber string, from time.Time, to time.Time, ctx context.Context) ([]domain.AccountPosting, error) {
+	return fakeLedger.find(accountNumber, from, to), nil
+}
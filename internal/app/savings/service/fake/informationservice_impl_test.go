@@ -1,15 +1,19 @@
 package fake_test
 
 import (
+	"context"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
 	"github.com/tunaiku/mobilebanking/internal/app/savings/service/fake"
+	"go.uber.org/zap"
 )
 
 func TestFindTransactionDetailByCode_Should_ReturnTransactionDetail_When_TransactionIsAvailabelOnTheSystem(t *testing.T) {
 	service := fake.NewFakeTransactionInformationService()
-	detail, err := service.FindTransactionDetailByCode("T001")
+	detail, err := service.FindTransactionDetailByCode("T001", context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -20,7 +24,7 @@ func TestFindTransactionDetailByCode_Should_ReturnTransactionDetail_When_Transac
 
 func TestFindTransactionDetailByCode_Should_ReturnErrTransactionDetailNotFound_When_TheTransactionCodeIsInvalid(t *testing.T) {
 	service := fake.NewFakeTransactionInformationService()
-	_, err := service.FindTransactionDetailByCode("T003")
+	_, err := service.FindTransactionDetailByCode("T003", context.Background())
 	if err == nil || err != domain.ErrTransactionDetailNotFound {
 		t.Fatal("err should be `domain.ErrTransactionDetailNotFound`")
 	}
@@ -28,7 +32,7 @@ func TestFindTransactionDetailByCode_Should_ReturnErrTransactionDetailNotFound_W
 
 func TestIsAccountExists_Should_ReturnTrue_When_TheAccountIsAvailableOnTheSystem(t *testing.T) {
 	service := fake.NewFakeAccountInformationService()
-	isExists := service.IsAccountExists("10001")
+	isExists := service.IsAccountExists("10001", context.Background())
 	if !isExists {
 		t.Fatal("account should be exists")
 	}
@@ -36,7 +40,7 @@ func TestIsAccountExists_Should_ReturnTrue_When_TheAccountIsAvailableOnTheSystem
 
 func TestIsAccountExists_Should_ReturnFalse_When_TheAccountNumberIsInvalid(t *testing.T) {
 	service := fake.NewFakeAccountInformationService()
-	isExists := service.IsAccountExists("10003")
+	isExists := service.IsAccountExists("10003", context.Background())
 	if isExists {
 		t.Fatal("account shouldn't be exists")
 	}
@@ -44,7 +48,7 @@ func TestIsAccountExists_Should_ReturnFalse_When_TheAccountNumberIsInvalid(t *te
 
 func TestGetTransactionPrivileges_ShouldReturn_TwoTransactionCode_When_TheAccountIsAvailableOnTheSystem(t *testing.T) {
 	service := fake.NewFakeAccountInformationService()
-	privileges, err := service.GetTransactionPrivileges("10001")
+	privileges, err := service.GetTransactionPrivileges("10001", context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -56,9 +60,73 @@ func TestGetTransactionPrivileges_ShouldReturn_TwoTransactionCode_When_TheAccoun
 
 func TestGetTransactionPrivileges_Should_ReturnErrAccountNotFound_When_TheAccountNotIsInvalid(t *testing.T) {
 	service := fake.NewFakeAccountInformationService()
-	_, err := service.GetTransactionPrivileges("10003")
+	_, err := service.GetTransactionPrivileges("10003", context.Background())
 	if err == nil || err != domain.ErrAccountNotFound {
 		t.Fatal("err should be `domain.ErrAccountNotFound`")
 	}
 
 }
+
+func TestInquireAccount_Should_ReturnHolderNameAndStatus_When_TheAccountIsAvailableOnTheSystem(t *testing.T) {
+	service := fake.NewFakeAccountInformationService()
+	inquiry, err := service.InquireAccount("10002", context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inquiry.HolderName != "Jane Doe" || inquiry.Status != domain.AccountActive {
+		t.Fatal("holder name should be `Jane Doe` and the account should be active")
+	}
+}
+
+func TestInquireAccount_Should_ReturnErrAccountNotFound_When_TheAccountNumberIsInvalid(t *testing.T) {
+	service := fake.NewFakeAccountInformationService()
+	_, err := service.InquireAccount("10003", context.Background())
+	if err == nil || err != domain.ErrAccountNotFound {
+		t.Fatal("err should be `domain.ErrAccountNotFound`")
+	}
+}
+
+func TestGetAccount_Should_ReturnBalances_When_TheAccountIsAvailableOnTheSystem(t *testing.T) {
+	service := fake.NewFakeAccountInformationService()
+	account, err := service.GetAccount("10001", context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if account.AvailableBalance == nil || account.LedgerBalance == nil || account.Currency != "IDR" {
+		t.Fatal("balances shouldn't be nil and the currency should be `IDR`")
+	}
+}
+
+func TestGetAccount_Should_ReturnErrAccountNotFound_When_TheAccountNumberIsInvalid(t *testing.T) {
+	service := fake.NewFakeAccountInformationService()
+	_, err := service.GetAccount("10003", context.Background())
+	if err == nil || err != domain.ErrAccountNotFound {
+		t.Fatal("err should be `domain.ErrAccountNotFound`")
+	}
+}
+
+func TestFindPostings_Should_ReturnDebitAndCredit_When_ATransactionWasCreated(t *testing.T) {
+	transactionDate := time.Date(2020, time.August, 3, 10, 0, 0, 0, time.UTC)
+	err := fake.NewFakeTransactionService(zap.NewNop()).CreateTransaction(domain.TransactionCreation{
+		SourceAccount:      "10001",
+		DestinationAccount: "10002",
+		TransactionCode:    "T001",
+		Amount:             big.NewFloat(3000),
+		Currency:           "IDR",
+		TransactionDate:    &transactionDate,
+		Reference:          "ref-001",
+	}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := fake.NewFakeTransactionInformationService()
+	from := time.Date(2020, time.August, 1, 0, 0, 0, 0, time.UTC)
+	debits, _ := service.FindPostings("10001", from, from.AddDate(0, 1, 0), context.Background())
+	credits, _ := service.FindPostings("10002", from, from.AddDate(0, 1, 0), context.Background())
+	if len(debits) != 1 || debits[0].Amount.Sign() >= 0 || len(credits) != 1 || credits[0].Amount.Sign() <= 0 {
+		t.Fatal("source account should be debited and destination account should be credited")
+	}
+}
@@ -0,0 +1,80 @@
+package fake
+
+import (
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+// ledger keeps the postings booked by the fake core in memory
+type ledger struct {
+	sync.RWMutex
+	postings []domain.AccountPosting
+}
+
+var fakeLedger = &ledger{
+	postings: []domain.AccountPosting{
+		{
+			AccountNumber: "10001",
+			Reference:     "INT-202007-10001",
+			Description:   "interest",
+			Amount:        big.NewFloat(1250),
+			PostedAt:      time.Date(2020, time.July, 31, 23, 59, 0, 0, time.UTC),
+		},
+	},
+}
+
+// record books the transfer once, a transfer already booked under the same reference is ignored
+func (l *ledger) record(transactionCreation domain.TransactionCreation) {
+	postedAt := time.Now().UTC()
+	if transactionCreation.TransactionDate != nil {
+		postedAt = transactionCreation.TransactionDate.UTC()
+	}
+	debit := new(big.Float).Neg(transactionCreation.Amount)
+
+	l.Lock()
+	defer l.Unlock()
+	for _, posting := range l.postings {
+		if posting.Reference == transactionCreation.Reference {
+			return
+		}
+	}
+	l.postings = append(l.postings,
+		domain.AccountPosting{
+			AccountNumber: transactionCreation.SourceAccount,
+			Reference:     transactionCreation.Reference,
+			Description:   "transfer to " + transactionCreation.DestinationAccount,
+			Amount:        debit,
+			PostedAt:      postedAt,
+		},
+		domain.AccountPosting{
+			AccountNumber: transactionCreation.DestinationAccount,
+			Reference:     transactionCreation.Reference,
+			Description:   "transfer from " + transactionCreation.SourceAccount,
+			Amount:        transactionCreation.Amount,
+			PostedAt:      postedAt,
+		})
+	if transactionCreation.Fee != nil && transactionCreation.Fee.Sign() > 0 {
+		l.postings = append(l.postings, domain.AccountPosting{
+			AccountNumber: transactionCreation.SourceAccount,
+			Reference:     transactionCreation.Reference,
+			Description:   "transfer fee",
+			Amount:        new(big.Float).Neg(transactionCreation.Fee),
+			PostedAt:      postedAt,
+		})
+	}
+}
+
+func (l *ledger) find(accountNumber string, from time.Time, to time.Time) []domain.AccountPosting {
+	l.RLock()
+	defer l.RUnlock()
+	var postings []domain.AccountPosting
+	for _, posting := range l.postings {
+		if posting.AccountNumber == accountNumber && !posting.PostedAt.Before(from) && posting.PostedAt.Before(to) {
+			postings = append(postings, posting)
+		}
+	}
+	return postings
+}
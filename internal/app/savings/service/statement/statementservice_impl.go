@@ -0,0 +1,183 @@
+package statement
+
+import (
+	"context"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+// StatementServiceImpl builds statements from the transactions persisted by this service, merged with
+// the postings the core booked on its own such as interest or teller deposits.
+type StatementServiceImpl struct {
+	accountInformationService     domain.AccountInformationService
+	transactionInformationService domain.TransactionInformationService
+	transactionRepository         domain.TransactionRepository
+}
+
+func NewStatementServiceImpl(accountInformationService domain.AccountInformationService,
+	transactionInformationService domain.TransactionInformationService,
+	transactionRepository domain.TransactionRepository) *StatementServiceImpl {
+	return &StatementServiceImpl{
+		accountInformationService:     accountInformationService,
+		transactionInformationService: transactionInformationService,
+		transactionRepository:         transactionRepository,
+	}
+}
+
+// Summarize derives the opening balance by rolling the current ledger balance back to the start of the period.
+func (impl *StatementServiceImpl) Summarize(accountNumber string, from time.Time, to time.Time,
+	ctx context.Context) (domain.StatementSummary, error) {
+	account, err := impl.accountInformationService.GetAccount(accountNumber, ctx)
+	if err != nil {
+		return domain.StatementSummary{}, err
+	}
+
+	now := time.Now().UTC()
+	if to.After(now) {
+		to = now
+	}
+
+	sinceFrom, err := impl.netMovement(ctx, accountNumber, from, now)
+	if err != nil {
+		return domain.StatementSummary{}, err
+	}
+
+	inPeriod, err := impl.netMovement(ctx, accountNumber, from, to)
+	if err != nil {
+		return domain.StatementSummary{}, err
+	}
+
+	opening := new(big.Float).Sub(account.LedgerBalance, sinceFrom)
+	return domain.StatementSummary{
+		AccountNumber:  accountNumber,
+		Currency:       account.Currency,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		ClosingBalance: new(big.Float).Add(opening, inPeriod),
+	}, nil
+}
+
+// EachEntry calls fn for every movement of the period in posting order, rows are read from the
+// database one at a time so large periods are never loaded in memory at once.
+func (impl *StatementServiceImpl) EachEntry(summary domain.StatementSummary, fn func(entry domain.StatementEntry) error,
+	ctx context.Context) error {
+	postings, err := impl.externalPostings(ctx, summary.AccountNumber, summary.From, summary.To)
+	if err != nil {
+		return err
+	}
+
+	balance := new(big.Float).Set(summary.OpeningBalance)
+	emit := func(postedAt time.Time, reference string, description string, amount *big.Float) error {
+		balance = new(big.Float).Add(balance, amount)
+		entry := domain.StatementEntry{
+			PostedAt:    postedAt,
+			Reference:   reference,
+			Description: description,
+			Debit:       new(big.Float),
+			Credit:      new(big.Float),
+			Balance:     balance,
+		}
+		if amount.Sign() < 0 {
+			entry.Debit.Neg(amount)
+		} else {
+			entry.Credit.Set(amount)
+		}
+		return fn(entry)
+	}
+	emitPostingsBefore := func(at time.Time) error {
+		for len(postings) > 0 && postings[0].PostedAt.Before(at) {
+			posting := postings[0]
+			postings = postings[1:]
+			if err := emit(posting.PostedAt, posting.Reference, posting.Description, posting.Amount); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	err = impl.transactionRepository.ForEachOfAccount(ctx, summary.AccountNumber, summary.From, summary.To,
+		func(transaction *domain.Transaction) error {
+			bookedAt := transaction.BookedAt()
+			if err := emitPostingsBefore(bookedAt); err != nil {
+				return err
+			}
+			amount := big.NewFloat(transaction.Amount)
+			description := "transfer from " + transaction.SourceAccount
+			if transaction.SourceAccount == summary.AccountNumber {
+				amount.Neg(amount)
+				description = "transfer to " + transaction.DestinationAccount
+				if transaction.DestinationName != "" {
+					description += " " + transaction.DestinationName
+				}
+			}
+			if err := emit(bookedAt, transaction.ID, description, amount); err != nil {
+				return err
+			}
+			if transaction.SourceAccount == summary.AccountNumber && transaction.Fee > 0 {
+				return emit(bookedAt, transaction.ID, "transfer fee", big.NewFloat(-transaction.Fee))
+			}
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+	return emitPostingsBefore(summary.To)
+}
+
+func (impl *StatementServiceImpl) netMovement(ctx context.Context, accountNumber string, from time.Time,
+	to time.Time) (*big.Float, error) {
+	net, err := impl.transactionRepository.NetMovementOfAccount(ctx, accountNumber, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	postings, err := impl.externalPostings(ctx, accountNumber, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	total := big.NewFloat(net)
+	for _, posting := range postings {
+		total.Add(total, posting.Amount)
+	}
+	return total, nil
+}
+
+// externalPostings returns the core postings which were not initiated through this service
+func (impl *StatementServiceImpl) externalPostings(ctx context.Context, accountNumber string, from time.Time,
+	to time.Time) ([]domain.AccountPosting, error) {
+	postings, err := impl.transactionInformationService.FindPostings(accountNumber, from, to, ctx)
+	if err != nil || len(postings) == 0 {
+		return nil, err
+	}
+
+	references := make([]string, 0, len(postings))
+	for _, posting := range postings {
+		references = append(references, posting.Reference)
+	}
+
+	known, err := impl.transactionRepository.FindExistingIDs(ctx, references)
+	if err != nil {
+		return nil, err
+	}
+
+	isKnown := make(map[string]bool, len(known))
+	for _, id := range known {
+		isKnown[id] = true
+	}
+
+	external := postings[:0]
+	for _, posting := range postings {
+		if !isKnown[posting.Reference] {
+			external = append(external, posting)
+		}
+	}
+	sort.SliceStable(external, func(i, j int) bool {
+		return external[i].PostedAt.Before(external[j].PostedAt)
+	})
+	return external, nil
+}
@@ -0,0 +1,133 @@
+package statement_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/service/statement"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/repository/inmemory"
+)
+
+type stubAccountInformationService struct {
+	domain.AccountInformationService
+	ledgerBalance float64
+}
+
+func (stub *stubAccountInformationService) GetAccount(accountNumber string, ctx context.Context) (domain.Account, error) {
+	return domain.Account{AccountNumber: accountNumber, Currency: "IDR", LedgerBalance: big.NewFloat(stub.ledgerBalance)}, nil
+}
+
+type stubTransactionInformationService struct {
+	domain.TransactionInformationService
+	postings []domain.AccountPosting
+}
+
+func (stub *stubTransactionInformationService) FindPostings(accountNumber string, from time.Time, to time.Time,
+	ctx context.Context) ([]domain.AccountPosting, error) {
+	var postings []domain.AccountPosting
+	for _, posting := range stub.postings {
+		if !posting.PostedAt.Before(from) && posting.PostedAt.Before(to) {
+			postings = append(postings, posting)
+		}
+	}
+	return postings, nil
+}
+
+func day(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 9, 0, 0, 0, time.UTC)
+}
+
+// newStatementService books for account 10001 in January a transfer with its fee, a transfer scheduled in
+// December for January, a received transfer and the interest, a transfer created in January but scheduled
+// for February and a teller deposit in February
+func newStatementService(t *testing.T) *statement.StatementServiceImpl {
+	repository := inmemory.NewInMemoryTransactionRepository(inmemory.NewDatastore())
+	scheduledInJanuary := day(time.January, 15)
+	scheduledInFebruary := day(time.February, 5)
+	transactions := []domain.Transaction{
+		{ID: "trx-1", State: domain.Success, Amount: 100000, Fee: 2500, SourceAccount: "10001", DestinationAccount: "10002",
+			CreatedAt: day(time.January, 10)},
+		{ID: "trx-2", State: domain.Success, Amount: 50000, SourceAccount: "10002", DestinationAccount: "10001",
+			CreatedAt: day(time.January, 20)},
+		{ID: "trx-3", State: domain.Success, Amount: 200000, SourceAccount: "10001", DestinationAccount: "10002",
+			CreatedAt: day(time.January, 25), ExecutionDate: &scheduledInFebruary},
+		{ID: "trx-4", State: domain.Success, Amount: 30000, SourceAccount: "10001", DestinationAccount: "10002",
+			CreatedAt: time.Date(2025, time.December, 28, 9, 0, 0, 0, time.UTC), ExecutionDate: &scheduledInJanuary},
+		{ID: "trx-5", State: domain.Failed, Amount: 70000, SourceAccount: "10001", DestinationAccount: "10002",
+			CreatedAt: day(time.January, 12)},
+	}
+	for i := range transactions {
+		if err := repository.Save(context.Background(), &transactions[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	postings := &stubTransactionInformationService{postings: []domain.AccountPosting{
+		{AccountNumber: "10001", Reference: "trx-1", Amount: big.NewFloat(-102500), PostedAt: day(time.January, 10)},
+		{AccountNumber: "10001", Reference: "interest-1", Description: "interest", Amount: big.NewFloat(1000),
+			PostedAt: day(time.January, 31)},
+		{AccountNumber: "10001", Reference: "teller-1", Description: "teller deposit", Amount: big.NewFloat(5000),
+			PostedAt: day(time.February, 2)},
+	}}
+	return statement.NewStatementServiceImpl(&stubAccountInformationService{ledgerBalance: 1000000}, postings, repository)
+}
+
+func TestSummarize_Should_RollTheLedgerBalanceBack_When_TheAccountMovedSinceThePeriod(t *testing.T) {
+	service := newStatementService(t)
+
+	summary, err := service.Summarize("10001", day(time.January, 1), day(time.February, 1), context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.OpeningBalance.Cmp(big.NewFloat(1276500)) != 0 {
+		t.Fatalf("opening balance should be 1276500 but was %s", summary.OpeningBalance.Text('f', 2))
+	}
+	if summary.ClosingBalance.Cmp(big.NewFloat(1195000)) != 0 {
+		t.Fatalf("closing balance should be 1195000 but was %s", summary.ClosingBalance.Text('f', 2))
+	}
+}
+
+func TestEachEntry_Should_MergeTheExternalPostingsOnTheirBookingDay_When_ThePeriodHasBoth(t *testing.T) {
+	service := newStatementService(t)
+	summary, err := service.Summarize("10001", day(time.January, 1), day(time.February, 1), context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []domain.StatementEntry
+	err = service.EachEntry(summary, func(entry domain.StatementEntry) error {
+		entries = append(entries, entry)
+		return nil
+	}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct {
+		reference string
+		postedAt  time.Time
+		balance   float64
+	}{
+		{"trx-1", day(time.January, 10), 1176500},
+		{"trx-1", day(time.January, 10), 1174000},
+		{"trx-4", day(time.January, 15), 1144000},
+		{"trx-2", day(time.January, 20), 1194000},
+		{"interest-1", day(time.January, 31), 1195000},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("expected %d entries, got %+v", len(expected), entries)
+	}
+	for i, entry := range entries {
+		if entry.Reference != expected[i].reference || !entry.PostedAt.Equal(expected[i].postedAt) ||
+			entry.Balance.Cmp(big.NewFloat(expected[i].balance)) != 0 {
+			t.Fatalf("entry %d should be %+v but was %s %s %s", i, expected[i], entry.Reference, entry.PostedAt,
+				entry.Balance.Text('f', 2))
+		}
+	}
+	if entries[len(entries)-1].Balance.Cmp(summary.ClosingBalance) != 0 {
+		t.Fatal("the running balance should end on the closing balance")
+	}
+}
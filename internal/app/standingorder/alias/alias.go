@@ -0,0 +1,62 @@
+package alias
+
+import (
+	"errors"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+const (
+	Daily      string = "daily"
+	Weekly     string = "weekly"
+	Monthly    string = "monthly"
+	PollPeriod        = time.Minute
+	// RunLease is how long a runner owns a due standing order it is executing before another runner may pick it up
+	RunLease = 5 * time.Minute
+)
+
+const (
+	WaitAuthorization string = "WaitAuthorization"
+	Active            string = "Active"
+	Cancelled         string = "Cancelled"
+	Completed         string = "Completed"
+	Executed          string = "Executed"
+	Failed            string = "Failed"
+	Skipped           string = "Skipped"
+)
+
+var Frequencies = map[string]domain.RecurrenceFrequency{
+	Daily:   domain.DailyRecurrence,
+	Weekly:  domain.WeeklyRecurrence,
+	Monthly: domain.MonthlyRecurrence,
+}
+
+var FrequencyNames = map[domain.RecurrenceFrequency]string{
+	domain.DailyRecurrence:   Daily,
+	domain.WeeklyRecurrence:  Weekly,
+	domain.MonthlyRecurrence: Monthly,
+}
+
+var StandingOrderState = map[domain.StandingOrderState]string{
+	domain.StandingOrderWaitAuthorization: WaitAuthorization,
+	domain.StandingOrderActive:            Active,
+	domain.StandingOrderCancelled:         Cancelled,
+	domain.StandingOrderCompleted:         Completed,
+}
+
+var RunStatus = map[domain.StandingOrderRunStatus]string{
+	domain.StandingOrderRunExecuted: Executed,
+	domain.StandingOrderRunFailed:   Failed,
+	domain.StandingOrderRunSkipped:  Skipped,
+}
+
+var (
+	ErrMessageStandingOrderNotFound    = errors.New("standing order not found")
+	ErrMessageFrequencyNotSupported    = errors.New("unsupported recurrence frequency")
+	ErrMessageStartDateInPast          = errors.New("start date must be in the future")
+	ErrMessageEndDateBeforeStartDate   = errors.New("end date must be after the start date")
+	ErrMessageStandingOrderHadVerified = errors.New("standing order already authorized")
+	ErrMessageStandingOrderClosed      = errors.New("standing order is no longer active")
+	ErrMessageMissedOccurrence         = errors.New("occurrence missed while the scheduler was not running")
+)
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type CreateStandingOrderDto struct {
+	TransactionCode    string     `json:"transaction_code"`
+	Amount             float64    `json:"amount"`
+	DestinationAccount string     `json:"destination_account"`
+	AuthMethod         string     `json:"auth_method"`
+	Frequency          string     `json:"frequency"`
+	StartDate          time.Time  `json:"start_date"`
+	EndDate            *time.Time `json:"end_date"`
+}
+
+func (dto *CreateStandingOrderDto) Bind(req *http.Request) error {
+	if err := json.NewDecoder(req.Body).Decode(dto); err != nil {
+		return err
+	}
+	return nil
+}
+
+type UpdateStandingOrderDto struct {
+	ID      string     `json:"-"`
+	Amount  float64    `json:"amount"`
+	EndDate *time.Time `json:"end_date"`
+}
+
+func (dto *UpdateStandingOrderDto) Bind(req *http.Request) error {
+	if err := json.NewDecoder(req.Body).Decode(dto); err != nil {
+		return err
+	}
+	return nil
+}
+
+type VerifyStandingOrderDto struct {
+	ID          string             `json:"ID"`
+	Session     domain.UserSession `json:"session"`
+	Credential  string             `json:"credential"`
+	Credentials map[string]string  `json:"credentials"`
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/dto"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/services"
+	trxAlias "github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+)
+
+type StandingOrderEndpoint struct {
+	userSessionHelper    domain.UserSessionHelper
+	standingOrderService services.StandingOrderService
+	limiter              *ratelimit.Limiter
+	authority            *jwt.Authority
+}
+
+func NewStandingOrderEndpoint(
+	userSessionHelper domain.UserSessionHelper,
+	standingOrderService services.StandingOrderService,
+	limiter *ratelimit.Limiter,
+	authority *jwt.Authority) *StandingOrderEndpoint {
+	return &StandingOrderEndpoint{
+		userSessionHelper:    userSessionHelper,
+		standingOrderService: standingOrderService,
+		limiter:              limiter,
+		authority:            authority,
+	}
+}
+
+func (endpoint *StandingOrderEndpoint) BindRoutes(r chi.Router) {
+	r.Group(func(r chi.Router) {
+		r = endpoint.authority.WrapChiRouterWithAuthorization(r)
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				next.ServeHTTP(w, r)
+			})
+		})
+		r.With(endpoint.limiter.Limit(ratelimit.GroupOtp)).Post("/standing-orders", endpoint.HandleCreateStandingOrder)
+		r.Get("/standing-orders", endpoint.HandleListStandingOrder)
+		r.Get("/standing-orders/{id}", endpoint.HandleGetStandingOrder)
+		r.With(endpoint.limiter.Limit(ratelimit.GroupOtp)).Put("/standing-orders/{id}", endpoint.HandleUpdateStandingOrder)
+		r.Delete("/standing-orders/{id}", endpoint.HandleCancelStandingOrder)
+		r.Put("/standing-orders/{id}/verify", endpoint.HandleVerifyStandingOrder)
+		r.Get("/standing-orders/{id}/runs", endpoint.HandleListStandingOrderRun)
+	})
+}
+
+func (endpoint *StandingOrderEndpoint) HandleCreateStandingOrder(w http.ResponseWriter, r *http.Request) {
+	requestDto := &dto.CreateStandingOrderDto{}
+	if err := requestDto.Bind(r); err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	order, err := endpoint.standingOrderService.Create(requestDto, r.Context())
+	if err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	render.JSON(w, r, toStandingOrderPayload(order))
+}
+
+func (endpoint *StandingOrderEndpoint) HandleListStandingOrder(w http.ResponseWriter, r *http.Request) {
+	orders, err := endpoint.standingOrderService.List(r.Context())
+	if err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	response := &ListStandingOrderSuccess{StandingOrders: []StandingOrderPayload{}}
+	for i := range orders {
+		response.StandingOrders = append(response.StandingOrders, toStandingOrderPayload(&orders[i]))
+	}
+	render.JSON(w, r, response)
+}
+
+func (endpoint *StandingOrderEndpoint) HandleGetStandingOrder(w http.ResponseWriter, r *http.Request) {
+	order, err := endpoint.standingOrderService.Get(chi.URLParam(r, "id"), r.Context())
+	if err != nil {
+		renderFailed(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	render.JSON(w, r, toStandingOrderPayload(order))
+}
+
+func (endpoint *StandingOrderEndpoint) HandleUpdateStandingOrder(w http.ResponseWriter, r *http.Request) {
+	requestDto := &dto.UpdateStandingOrderDto{}
+	if err := requestDto.Bind(r); err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+	requestDto.ID = chi.URLParam(r, "id")
+
+	order, err := endpoint.standingOrderService.Update(requestDto, r.Context())
+	if err != nil {
+		renderFailed(w, r, statusOf(err), err)
+		return
+	}
+
+	render.JSON(w, r, toStandingOrderPayload(order))
+}
+
+func (endpoint *StandingOrderEndpoint) HandleCancelStandingOrder(w http.ResponseWriter, r *http.Request) {
+	if err := endpoint.standingOrderService.Cancel(chi.URLParam(r, "id"), r.Context()); err != nil {
+		renderFailed(w, r, statusOf(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (endpoint *StandingOrderEndpoint) HandleVerifyStandingOrder(w http.ResponseWriter, r *http.Request) {
+	userSession, err := endpoint.userSessionHelper.GetFromContext(r.Context())
+	if err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	request := VerifyStandingOrderRequest{}
+	if err := request.Bind(r); err != nil {
+		renderFailed(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	verifyDto := &dto.VerifyStandingOrderDto{
+		ID:          chi.URLParam(r, "id"),
+		Session:     userSession,
+		Credential:  request.Credential,
+		Credentials: request.Credentials,
+	}
+	order, err := endpoint.standingOrderService.Verify(verifyDto, r.Context())
+	if err != nil {
+		renderFailed(w, r, statusOf(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	render.JSON(w, r, &VerifyStandingOrderSuccess{
+		StandingOrderID:    order.ID,
+		State:              alias.StandingOrderState[order.State],
+		PendingAuthMethods: authMethodNames(order.PendingAuthorizations()),
+	})
+}
+
+func (endpoint *StandingOrderEndpoint) HandleListStandingOrderRun(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	runs, err := endpoint.standingOrderService.GetRuns(id, r.Context())
+	if err != nil {
+		renderFailed(w, r, statusOf(err), err)
+		return
+	}
+
+	response := &ListStandingOrderRunSuccess{StandingOrderID: id, Runs: []StandingOrderRunPayload{}}
+	for _, run := range runs {
+		response.Runs = append(response.Runs, StandingOrderRunPayload{
+			Occurrence:    run.Occurrence,
+			ScheduledAt:   run.ScheduledAt,
+			Status:        alias.RunStatus[run.Status],
+			TransactionID: run.TransactionID,
+			Reason:        run.Reason,
+		})
+	}
+	render.JSON(w, r, response)
+}
+
+func toStandingOrderPayload(order *domain.StandingOrder) StandingOrderPayload {
+	return StandingOrderPayload{
+		ID:                  order.ID,
+		State:               alias.StandingOrderState[order.State],
+		AuthMethod:          trxAlias.AuthMethodNames[order.AuthorizationMethod],
+		RequiredAuthMethods: authMethodNames(order.RequiredAuthorizations),
+		TransactionCode:     order.TransactionCode,
+		Amount:              order.Amount,
+		SourceAccount:       order.SourceAccount,
+		DestinationAccount:  order.DestinationAccount,
+		Frequency:           alias.FrequencyNames[order.Frequency],
+		StartDate:           order.StartDate,
+		EndDate:             order.EndDate,
+		NextRunAt:           order.NextRunAt,
+	}
+}
+
+func authMethodNames(methods []domain.AuthorizationMethod) []string {
+	names := []string{}
+	for _, method := range methods {
+		names = append(names, trxAlias.AuthMethodNames[method])
+	}
+	return names
+}
+
+func statusOf(err error) int {
+	if err == alias.ErrMessageStandingOrderNotFound {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
+
+func renderFailed(w http.ResponseWriter, r *http.Request, status int, err error) {
+	w.WriteHeader(status)
+	render.JSON(w, r, &StandingOrderHandlerFailed{Message: err.Error()})
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type StandingOrderHandlerFailed struct {
+	Message string `json:"message"`
+}
+
+type VerifyStandingOrderRequest struct {
+	Credential  string            `json:"credential"`
+	Credentials map[string]string `json:"credentials"`
+}
+
+func (payload *VerifyStandingOrderRequest) Bind(req *http.Request) error {
+	if err := json.NewDecoder(req.Body).Decode(payload); err != nil {
+		return err
+	}
+	return nil
+}
+
+type StandingOrderPayload struct {
+	ID                  string     `json:"id"`
+	State               string     `json:"state"`
+	AuthMethod          string     `json:"auth_method"`
+	RequiredAuthMethods []string   `json:"required_auth_methods"`
+	TransactionCode     string     `json:"transaction_code"`
+	Amount              float64    `json:"amount"`
+	SourceAccount       string     `json:"source_account"`
+	DestinationAccount  string     `json:"destination_account"`
+	Frequency           string     `json:"frequency"`
+	StartDate           time.Time  `json:"start_date"`
+	EndDate             *time.Time `json:"end_date,omitempty"`
+	NextRunAt           *time.Time `json:"next_run_at,omitempty"`
+}
+
+type ListStandingOrderSuccess struct {
+	StandingOrders []StandingOrderPayload `json:"standing_orders"`
+}
+
+type VerifyStandingOrderSuccess struct {
+	StandingOrderID    string   `json:"standing_order_id"`
+	State              string   `json:"state"`
+	PendingAuthMethods []string `json:"pending_auth_methods"`
+}
+
+type StandingOrderRunPayload struct {
+	Occurrence    int       `json:"occurrence"`
+	ScheduledAt   time.Time `json:"scheduled_at"`
+	Status        string    `json:"status"`
+	TransactionID string    `json:"transaction_id,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
+}
+
+type ListStandingOrderRunSuccess struct {
+	StandingOrderID string                    `json:"standing_order_id"`
+	Runs            []StandingOrderRunPayload `json:"runs"`
+}
@@ -0,0 +1,63 @@
+package standingorder
+
+import (
+	"log"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/handler"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/repository/postgres"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/services"
+	trxServices "github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func() domain.StandingOrderRepository {
+		return postgres.NewPostgresStandingOrderRepository()
+	})
+
+	container.Provide(func(userSession domain.UserSessionHelper, otpCredentialManager domain.OtpCredentialManager,
+		pinCredentialManager domain.PinCredentialManager, authorizationPolicy domain.AuthorizationPolicy,
+		standingOrders domain.StandingOrderRepository, transactions domain.TransactionRepository,
+		unitOfWork domain.UnitOfWork) services.StandingOrderService {
+		return services.NewStandingOrderService(userSession, otpCredentialManager, pinCredentialManager,
+			authorizationPolicy, standingOrders, transactions, unitOfWork)
+	})
+
+	container.Provide(func(standingOrders domain.StandingOrderRepository, userRepository domain.UserRepository,
+		transactionService trxServices.TransactionCompositionService, unitOfWork domain.UnitOfWork,
+		logger *zap.Logger) services.StandingOrderRunner {
+		return services.NewStandingOrderRunner(standingOrders, userRepository, transactionService, unitOfWork, logger)
+	})
+
+	container.Provide(func(runner services.StandingOrderRunner) *services.StandingOrderScheduler {
+		return services.NewStandingOrderScheduler(runner, alias.PollPeriod)
+	})
+
+	container.Provide(func(
+		userSessionHelper domain.UserSessionHelper,
+		standingOrderService services.StandingOrderService,
+		limiter *ratelimit.Limiter,
+		authority *jwt.Authority) *handler.StandingOrderEndpoint {
+		return handler.NewStandingOrderEndpoint(userSessionHelper, standingOrderService, limiter, authority)
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(router chi.Router, endpoint *handler.StandingOrderEndpoint,
+		scheduler *services.StandingOrderScheduler, serverLifecycle *lifecycle.Lifecycle) {
+		log.Println("invoke standing order startup ...")
+		endpoint.BindRoutes(router)
+		scheduler.Start()
+		serverLifecycle.OnStop("standing order scheduler", scheduler.Shutdown)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,147 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type InMemoryStandingOrderRepository struct {
+	sync.Mutex
+	orders map[string]domain.StandingOrder
+	runs   []domain.StandingOrderRun
+}
+
+func NewInMemoryStandingOrderRepository() *InMemoryStandingOrderRepository {
+	return &InMemoryStandingOrderRepository{orders: map[string]domain.StandingOrder{}}
+}
+
+// clone copies the authorization slices so callers appending to them never write into the repository
+func clone(order domain.StandingOrder) domain.StandingOrder {
+	order.RequiredAuthorizations = append([]domain.AuthorizationMethod(nil), order.RequiredAuthorizations...)
+	order.CompletedAuthorizations = append([]domain.AuthorizationMethod(nil), order.CompletedAuthorizations...)
+	return order
+}
+
+func (inmem *InMemoryStandingOrderRepository) Insert(ctx context.Context, order *domain.StandingOrder) error {
+	inmem.Lock()
+	defer inmem.Unlock()
+	inmem.orders[order.ID] = clone(*order)
+	return nil
+}
+
+func (inmem *InMemoryStandingOrderRepository) FindByID(ctx context.Context, id string) (*domain.StandingOrder, error) {
+	inmem.Lock()
+	defer inmem.Unlock()
+	order, ok := inmem.orders[id]
+	if !ok {
+		return nil, domain.ErrStandingOrderNotFound
+	}
+	order = clone(order)
+	return &order, nil
+}
+
+func (inmem *InMemoryStandingOrderRepository) FindByUser(ctx context.Context, userID string) ([]domain.StandingOrder, error) {
+	inmem.Lock()
+	defer inmem.Unlock()
+	var orders []domain.StandingOrder
+	for _, order := range inmem.orders {
+		if order.UserID == userID {
+			orders = append(orders, clone(order))
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.After(orders[j].CreatedAt)
+	})
+	return orders, nil
+}
+
+func (inmem *InMemoryStandingOrderRepository) Update(ctx context.Context, order *domain.StandingOrder) error {
+	inmem.Lock()
+	defer inmem.Unlock()
+	stored, ok := inmem.orders[order.ID]
+	if !ok {
+		return nil
+	}
+	stored.State = order.State
+	stored.AuthorizationMethod = order.AuthorizationMethod
+	stored.RequiredAuthorizations = order.RequiredAuthorizations
+	stored.CompletedAuthorizations = order.CompletedAuthorizations
+	stored.Amount = order.Amount
+	stored.EndDate = order.EndDate
+	stored.NextRunAt = order.NextRunAt
+	inmem.orders[order.ID] = clone(stored)
+	return nil
+}
+
+func (inmem *InMemoryStandingOrderRepository) FindDue(ctx context.Context, now time.Time) ([]domain.StandingOrder, error) {
+	inmem.Lock()
+	defer inmem.Unlock()
+	var orders []domain.StandingOrder
+	for _, order := range inmem.orders {
+		if order.State == domain.StandingOrderActive && order.NextRunAt != nil && !order.NextRunAt.After(now) {
+			orders = append(orders, clone(order))
+		}
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].NextRunAt.Before(*orders[j].NextRunAt)
+	})
+	return orders, nil
+}
+
+func (inmem *InMemoryStandingOrderRepository) Claim(ctx context.Context, order *domain.StandingOrder, owner string,
+	now time.Time, leaseUntil time.Time) (bool, error) {
+	inmem.Lock()
+	defer inmem.Unlock()
+	stored, ok := inmem.orders[order.ID]
+	if !ok || stored.State != domain.StandingOrderActive || stored.NextRunAt == nil || stored.NextRunAt.After(now) {
+		return false, nil
+	}
+	stored.NextRunAt = &leaseUntil
+	stored.LeaseOwner = owner
+	inmem.orders[order.ID] = stored
+	*order = clone(stored)
+	return true, nil
+}
+
+func (inmem *InMemoryStandingOrderRepository) Advance(ctx context.Context, order *domain.StandingOrder) error {
+	inmem.Lock()
+	defer inmem.Unlock()
+	stored, ok := inmem.orders[order.ID]
+	if !ok || stored.LeaseOwner == "" || stored.LeaseOwner != order.LeaseOwner {
+		return domain.ErrStandingOrderLeaseLost
+	}
+	stored.NextOccurrence = order.NextOccurrence
+	if stored.State == domain.StandingOrderActive {
+		stored.State = order.State
+		stored.NextRunAt = order.NextRunAt
+	}
+	stored.LeaseOwner = ""
+	inmem.orders[order.ID] = stored
+	return nil
+}
+
+func (inmem *InMemoryStandingOrderRepository) InsertRun(ctx context.Context, run *domain.StandingOrderRun) error {
+	inmem.Lock()
+	defer inmem.Unlock()
+	inmem.runs = append(inmem.runs, *run)
+	return nil
+}
+
+func (inmem *InMemoryStandingOrderRepository) FindRuns(ctx context.Context, standingOrderID string) ([]domain.StandingOrderRun, error) {
+	inmem.Lock()
+	defer inmem.Unlock()
+	var runs []domain.StandingOrderRun
+	for _, run := range inmem.runs {
+		if run.StandingOrderID == standingOrderID {
+			runs = append(runs, run)
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Occurrence < runs[j].Occurrence
+	})
+	return runs, nil
+}
@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	appPg "github.com/tunaiku/mobilebanking/internal/pkg/pg"
+)
+
+type PostgresStandingOrderRepository struct {
+}
+
+func NewPostgresStandingOrderRepository() *PostgresStandingOrderRepository {
+	return &PostgresStandingOrderRepository{}
+}
+
+func (repo *PostgresStandingOrderRepository) Insert(ctx context.Context, order *domain.StandingOrder) error {
+	return appPg.FromContext(ctx).Insert(order)
+}
+
+func (repo *PostgresStandingOrderRepository) FindByID(ctx context.Context, id string) (*domain.StandingOrder, error) {
+	order := &domain.StandingOrder{ID: id}
+	err := appPg.FromContext(ctx).Load(order)
+	if err == pg.ErrNoRows {
+		return nil, domain.ErrStandingOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+func (repo *PostgresStandingOrderRepository) FindByUser(ctx context.Context, userID string) ([]domain.StandingOrder, error) {
+	var orders []domain.StandingOrder
+	err := appPg.FromContext(ctx).Query(&orders).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Select()
+	return orders, err
+}
+
+func (repo *PostgresStandingOrderRepository) Update(ctx context.Context, order *domain.StandingOrder) error {
+	_, err := appPg.FromContext(ctx).Query(order).
+		Column("state", "authorization_method", "required_authorizations", "completed_authorizations",
+			"amount", "end_date", "next_run_at").
+		WherePK().
+		Update()
+	return err
+}
+
+func (repo *PostgresStandingOrderRepository) FindDue(ctx context.Context, now time.Time) ([]domain.StandingOrder, error) {
+	var orders []domain.StandingOrder
+	err := appPg.FromContext(ctx).Query(&orders).
+		Where("state = ?", domain.StandingOrderActive).
+		Where("next_run_at <= ?", now).
+		Order("next_run_at ASC").
+		Select()
+	return orders, err
+}
+
+func (repo *PostgresStandingOrderRepository) Claim(ctx context.Context, order *domain.StandingOrder, owner string,
+	now time.Time, leaseUntil time.Time) (bool, error) {
+	result, err := appPg.FromContext(ctx).Query(order).
+		Set("next_run_at = ?", leaseUntil).
+		Set("lease_owner = ?", owner).
+		WherePK().
+		Where("state = ?", domain.StandingOrderActive).
+		Where("next_run_at <= ?", now).
+		Returning("*").
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (repo *PostgresStandingOrderRepository) Advance(ctx context.Context, order *domain.StandingOrder) error {
+	result, err := appPg.FromContext(ctx).Query((*domain.StandingOrder)(nil)).
+		Set("next_occurrence = ?", order.NextOccurrence).
+		Set("next_run_at = CASE WHEN state = ? THEN ? ELSE next_run_at END", domain.StandingOrderActive, order.NextRunAt).
+		Set("state = CASE WHEN state = ? THEN ? ELSE state END", domain.StandingOrderActive, order.State).
+		Set("lease_owner = NULL").
+		Where("id = ?", order.ID).
+		Where("lease_owner = ?", order.LeaseOwner).
+		Update()
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return domain.ErrStandingOrderLeaseLost
+	}
+	return nil
+}
+
+func (repo *PostgresStandingOrderRepository) InsertRun(ctx context.Context, run *domain.StandingOrderRun) error {
+	return appPg.FromContext(ctx).Insert(run)
+}
+
+func (repo *PostgresStandingOrderRepository) FindRuns(ctx context.Context, standingOrderID string) ([]domain.StandingOrderRun, error) {
+	var runs []domain.StandingOrderRun
+	err := appPg.FromContext(ctx).Query(&runs).
+		Where("standing_order_id = ?", standingOrderID).
+		Order("occurrence ASC").
+		Select()
+	return runs, err
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/alias"
+	trxAlias "github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	trxDto "github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
+	trxServices "github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+	"github.com/tunaiku/mobilebanking/internal/pkg/scheduler"
+	"go.uber.org/zap"
+)
+
+type StandingOrderRunner interface {
+	ExecuteDueStandingOrders(now time.Time, ctx context.Context) error
+}
+
+type StandingOrderRunnerImp struct {
+	standingOrders     domain.StandingOrderRepository
+	userRepository     domain.UserRepository
+	transactionService trxServices.TransactionCompositionService
+	unitOfWork         domain.UnitOfWork
+	logger             *zap.Logger
+}
+
+func NewStandingOrderRunner(standingOrders domain.StandingOrderRepository, userRepository domain.UserRepository,
+	transactionService trxServices.TransactionCompositionService, unitOfWork domain.UnitOfWork,
+	logger *zap.Logger) StandingOrderRunner {
+	return &StandingOrderRunnerImp{standingOrders: standingOrders, userRepository: userRepository,
+		transactionService: transactionService, unitOfWork: unitOfWork, logger: logger}
+}
+
+func (runner *StandingOrderRunnerImp) ExecuteDueStandingOrders(now time.Time, ctx context.Context) error {
+	orders, err := runner.standingOrders.FindDue(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range orders {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		order := &orders[i]
+		claimed, err := runner.standingOrders.Claim(ctx, order, uuid.New().String(), now, now.Add(alias.RunLease))
+		if err != nil || !claimed {
+			if err != nil {
+				runner.logger.Error("standing order claim failed", zap.String("standing_order_id", order.ID), zap.Error(err))
+			}
+			continue
+		}
+		// a claimed order is executed to the end even when ctx is cancelled meanwhile, its transfers and
+		// the record of its runs must not be split by a shutdown
+		if err := runner.execute(context.Background(), order, now); err != nil {
+			runner.logger.Error("standing order failed", zap.String("standing_order_id", order.ID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// execute spawns a transaction for the latest due occurrence of the order. Older occurrences
+// which were missed, e.g. while the service was down, are reported as skipped instead of
+// being executed all at once. The order is leased to the run by its claim, a runner which read the
+// same order before loses the claim and leaves it alone. The progress is written back under the lease
+// so a cancellation or an update made by the user meanwhile is kept.
+func (runner *StandingOrderRunnerImp) execute(ctx context.Context, order *domain.StandingOrder, now time.Time) error {
+	var runs []*domain.StandingOrderRun
+	for {
+		scheduledAt := order.Occurrence(order.NextOccurrence)
+		if scheduledAt.After(now) || !order.IsWithinPeriod(scheduledAt) {
+			break
+		}
+
+		run := &domain.StandingOrderRun{
+			ID:              uuid.New().String(),
+			StandingOrderID: order.ID,
+			Occurrence:      order.NextOccurrence,
+			ScheduledAt:     scheduledAt,
+			CreatedAt:       now,
+		}
+		next := order.Occurrence(order.NextOccurrence + 1)
+		if !next.After(now) && order.IsWithinPeriod(next) {
+			run.Status = domain.StandingOrderRunSkipped
+			run.Reason = alias.ErrMessageMissedOccurrence.Error()
+		} else {
+			runner.run(ctx, order, run)
+		}
+		runs = append(runs, run)
+		order.NextOccurrence++
+	}
+
+	nextRunAt := order.Occurrence(order.NextOccurrence)
+	if order.IsWithinPeriod(nextRunAt) {
+		order.NextRunAt = &nextRunAt
+	} else {
+		order.State = domain.StandingOrderCompleted
+		order.NextRunAt = nil
+	}
+
+	return runner.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if err := runner.standingOrders.Advance(ctx, order); err != nil {
+			return err
+		}
+		for _, run := range runs {
+			if err := runner.standingOrders.InsertRun(ctx, run); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (runner *StandingOrderRunnerImp) run(ctx context.Context, order *domain.StandingOrder, run *domain.StandingOrderRun) {
+	user, err := runner.userRepository.LoadUser(order.UserID)
+	if err != nil || user == nil {
+		run.Status = domain.StandingOrderRunFailed
+		run.Reason = domain.ErrUserNotFound.Error()
+		return
+	}
+
+	transaction, err := runner.transactionService.ExecuteAuthorizedTransaction(&trxDto.CreateTransactionDto{
+		TransactionCode:    order.TransactionCode,
+		Amount:             order.Amount,
+		SourceAccount:      order.SourceAccount,
+		DestinationAccount: order.DestinationAccount,
+		AuthMethod:         trxAlias.AuthMethodNames[order.AuthorizationMethod],
+	}, domain.UserSession{User: user}, ctx)
+	if transaction != nil {
+		run.TransactionID = transaction.ID
+	}
+	if err != nil {
+		run.Status = domain.StandingOrderRunFailed
+		run.Reason = err.Error()
+		return
+	}
+	run.Status = domain.StandingOrderRunExecuted
+}
+
+// StandingOrderScheduler polls for active standing orders which are due and executes them.
+type StandingOrderScheduler struct {
+	*scheduler.Scheduler
+}
+
+func NewStandingOrderScheduler(runner StandingOrderRunner, interval time.Duration) *StandingOrderScheduler {
+	return &StandingOrderScheduler{scheduler.New("standing order", interval, runner.ExecuteDueStandingOrders)}
+}
@@ -0,0 +1,185 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/services"
+	trxDto "github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
+	trxInmemory "github.com/tunaiku/mobilebanking/internal/app/transaction/repository/inmemory"
+	trxServices "github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+	"go.uber.org/zap"
+)
+
+type stubUserRepository struct {
+	domain.UserRepository
+}
+
+func (stub *stubUserRepository) LoadUser(id string) (*domain.User, error) {
+	return &domain.User{ID: id}, nil
+}
+
+type stubTransactionCompositionService struct {
+	trxServices.TransactionCompositionService
+	executed []trxDto.CreateTransactionDto
+	// during runs while the transfer is executed, like a user acting on the order meanwhile
+	during func()
+}
+
+func (stub *stubTransactionCompositionService) ExecuteAuthorizedTransaction(dto *trxDto.CreateTransactionDto,
+	userSession domain.UserSession, ctx context.Context) (*domain.Transaction, error) {
+	stub.executed = append(stub.executed, *dto)
+	if stub.during != nil {
+		stub.during()
+	}
+	return &domain.Transaction{ID: "trx-1"}, nil
+}
+
+type runnerFixture struct {
+	orders       *inmemory.InMemoryStandingOrderRepository
+	transactions *stubTransactionCompositionService
+	runner       services.StandingOrderRunner
+}
+
+func newRunnerFixture(t *testing.T, startDate time.Time) runnerFixture {
+	fixture := runnerFixture{
+		orders:       inmemory.NewInMemoryStandingOrderRepository(),
+		transactions: &stubTransactionCompositionService{},
+	}
+	fixture.runner = services.NewStandingOrderRunner(fixture.orders, &stubUserRepository{}, fixture.transactions,
+		trxInmemory.NewInMemoryUnitOfWork(), zap.NewNop())
+
+	err := fixture.orders.Insert(context.Background(), &domain.StandingOrder{
+		ID:                 "order-1",
+		UserID:             "user-1",
+		State:              domain.StandingOrderActive,
+		Amount:             50000,
+		DestinationAccount: "10002",
+		Frequency:          domain.DailyRecurrence,
+		StartDate:          startDate,
+		NextRunAt:          &startDate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fixture
+}
+
+func (fixture runnerFixture) order(t *testing.T) *domain.StandingOrder {
+	order, err := fixture.orders.FindByID(context.Background(), "order-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return order
+}
+
+func TestStandingOrderRunner_Should_ScheduleTheNextOccurrence_When_TheOrderIsExecuted(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	fixture := newRunnerFixture(t, now)
+
+	if err := fixture.runner.ExecuteDueStandingOrders(now, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	order := fixture.order(t)
+	if order.NextOccurrence != 1 || !order.NextRunAt.Equal(now.AddDate(0, 0, 1)) || order.LeaseOwner != "" {
+		t.Fatalf("the order should wait for its next occurrence with no lease, got %+v", order)
+	}
+	runs, err := fixture.orders.FindRuns(context.Background(), "order-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 1 || runs[0].Status != domain.StandingOrderRunExecuted {
+		t.Fatalf("a single executed run should be recorded, got %+v", runs)
+	}
+}
+
+func TestStandingOrderRunner_Should_NotExecuteTheOrder_When_AnotherRunClaimedIt(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	fixture := newRunnerFixture(t, now)
+	order := fixture.order(t)
+	claimed, err := fixture.orders.Claim(context.Background(), order, "another-run", now, now.Add(alias.RunLease))
+	if err != nil || !claimed {
+		t.Fatalf("the order should be claimed, got %v %v", claimed, err)
+	}
+
+	if err := fixture.runner.ExecuteDueStandingOrders(now, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(fixture.transactions.executed) != 0 {
+		t.Fatalf("the order should not be executed but %d transfers were made", len(fixture.transactions.executed))
+	}
+}
+
+func TestStandingOrderRunner_Should_KeepTheCancellation_When_TheUserCancelsDuringTheRun(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	fixture := newRunnerFixture(t, now)
+	fixture.transactions.during = func() {
+		order := fixture.order(t)
+		order.State = domain.StandingOrderCancelled
+		order.NextRunAt = nil
+		if err := fixture.orders.Update(context.Background(), order); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := fixture.runner.ExecuteDueStandingOrders(now, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	order := fixture.order(t)
+	if order.State != domain.StandingOrderCancelled || order.NextRunAt != nil {
+		t.Fatalf("the order should stay cancelled, got %+v", order)
+	}
+	if order.NextOccurrence != 1 || order.LeaseOwner != "" {
+		t.Fatalf("the run should be recorded and its lease released, got %+v", order)
+	}
+}
+
+func TestStandingOrderRunner_Should_KeepTheNewAmount_When_TheUserUpdatesTheOrderDuringTheRun(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	fixture := newRunnerFixture(t, now)
+	fixture.transactions.during = func() {
+		order := fixture.order(t)
+		order.Amount = 75000
+		order.State = domain.StandingOrderWaitAuthorization
+		order.NextRunAt = nil
+		if err := fixture.orders.Update(context.Background(), order); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := fixture.runner.ExecuteDueStandingOrders(now, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	order := fixture.order(t)
+	if order.Amount != 75000 || order.State != domain.StandingOrderWaitAuthorization || order.NextRunAt != nil {
+		t.Fatalf("the update should be kept, got %+v", order)
+	}
+}
+
+func TestStandingOrderRunner_Should_SkipTheMissedOccurrences_When_TheOrderIsLate(t *testing.T) {
+	startDate := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	now := startDate.AddDate(0, 0, 2)
+	fixture := newRunnerFixture(t, startDate)
+
+	if err := fixture.runner.ExecuteDueStandingOrders(now, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(fixture.transactions.executed) != 1 {
+		t.Fatalf("only the latest occurrence should be executed but %d transfers were made", len(fixture.transactions.executed))
+	}
+	runs, err := fixture.orders.FindRuns(context.Background(), "order-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) != 3 || runs[0].Status != domain.StandingOrderRunSkipped || runs[1].Status != domain.StandingOrderRunSkipped ||
+		runs[2].Status != domain.StandingOrderRunExecuted {
+		t.Fatalf("the missed occurrences should be skipped, got %+v", runs)
+	}
+	if order := fixture.order(t); order.NextOccurrence != 3 {
+		t.Fatalf("the order should wait for its fourth occurrence, got %+v", order)
+	}
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	bankAlias "github.com/tunaiku/mobilebanking/internal/app/bank/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder/dto"
+	trxAlias "github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	trxServices "github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+)
+
+type StandingOrderService interface {
+	Create(dto *dto.CreateStandingOrderDto, ctx context.Context) (*domain.StandingOrder, error)
+	Update(dto *dto.UpdateStandingOrderDto, ctx context.Context) (*domain.StandingOrder, error)
+	Verify(dto *dto.VerifyStandingOrderDto, ctx context.Context) (*domain.StandingOrder, error)
+	Cancel(id string, ctx context.Context) error
+	Get(id string, ctx context.Context) (*domain.StandingOrder, error)
+	List(ctx context.Context) ([]domain.StandingOrder, error)
+	GetRuns(id string, ctx context.Context) ([]domain.StandingOrderRun, error)
+}
+
+type StandingOrderServiceImp struct {
+	userSession          domain.UserSessionHelper
+	otpCredentialManager domain.OtpCredentialManager
+	pinCredentialManager domain.PinCredentialManager
+	authorizationPolicy  domain.AuthorizationPolicy
+	standingOrders       domain.StandingOrderRepository
+	transactions         domain.TransactionRepository
+	unitOfWork           domain.UnitOfWork
+}
+
+func NewStandingOrderService(userSession domain.UserSessionHelper, otpCredentialManager domain.OtpCredentialManager,
+	pinCredentialManager domain.PinCredentialManager, authorizationPolicy domain.AuthorizationPolicy,
+	standingOrders domain.StandingOrderRepository, transactions domain.TransactionRepository,
+	unitOfWork domain.UnitOfWork) StandingOrderService {
+	return &StandingOrderServiceImp{userSession: userSession, otpCredentialManager: otpCredentialManager,
+		pinCredentialManager: pinCredentialManager, authorizationPolicy: authorizationPolicy,
+		standingOrders: standingOrders, transactions: transactions, unitOfWork: unitOfWork}
+}
+
+func (service *StandingOrderServiceImp) Create(dto *dto.CreateStandingOrderDto, ctx context.Context) (*domain.StandingOrder, error) {
+	userSession, err := service.userSession.GetFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateCreation(dto, userSession); err != nil {
+		return nil, err
+	}
+
+	endDate := dto.EndDate
+	if endDate != nil {
+		utc := endDate.UTC()
+		endDate = &utc
+	}
+
+	order := &domain.StandingOrder{
+		ID:                 uuid.New().String(),
+		UserID:             userSession.ID,
+		TransactionCode:    dto.TransactionCode,
+		Amount:             dto.Amount,
+		SourceAccount:      userSession.AccountReference,
+		DestinationAccount: dto.DestinationAccount,
+		Frequency:          alias.Frequencies[dto.Frequency],
+		StartDate:          dto.StartDate.UTC(),
+		EndDate:            endDate,
+		CreatedAt:          time.Now().UTC(),
+	}
+
+	if err := service.requestAuthorization(order, dto.AuthMethod, userSession, ctx); err != nil {
+		return nil, err
+	}
+
+	if err := service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return service.standingOrders.Insert(ctx, order)
+	}); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Update changes the amount or the end date of the mandate, the user has to authorize it again
+// before the next occurrence is executed.
+func (service *StandingOrderServiceImp) Update(dto *dto.UpdateStandingOrderDto, ctx context.Context) (*domain.StandingOrder, error) {
+	userSession, err := service.userSession.GetFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := service.loadOwned(dto.ID, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if isClosed(order) {
+		return nil, alias.ErrMessageStandingOrderClosed
+	}
+
+	if err := trxServices.ValidateAmount(dto.Amount); err != nil {
+		return nil, err
+	}
+
+	if dto.EndDate != nil {
+		endDate := dto.EndDate.UTC()
+		if !endDate.After(order.StartDate) {
+			return nil, alias.ErrMessageEndDateBeforeStartDate
+		}
+		order.EndDate = &endDate
+	}
+
+	order.Amount = dto.Amount
+	order.NextRunAt = nil
+
+	authMethod := trxAlias.AuthMethodNames[order.AuthorizationMethod]
+	if err := service.requestAuthorization(order, authMethod, userSession, ctx); err != nil {
+		return nil, err
+	}
+
+	if err := service.save(ctx, order); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// Verify records the credentials of the pending methods, the mandate only becomes active once every
+// method required by the authorization policy is verified.
+func (service *StandingOrderServiceImp) Verify(dto *dto.VerifyStandingOrderDto, ctx context.Context) (*domain.StandingOrder, error) {
+	order, err := service.loadOwned(dto.ID, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if order.State != domain.StandingOrderWaitAuthorization {
+		return nil, alias.ErrMessageStandingOrderHadVerified
+	}
+
+	pending := order.PendingAuthorizations()
+	credentials := collectCredentials(dto, pending)
+	if len(credentials) == 0 {
+		return nil, trxAlias.ErrMessageCredentialRequired
+	}
+
+	for _, method := range pending {
+		credential, ok := credentials[method]
+		if !ok {
+			continue
+		}
+		if err := service.validateCredential(order, method, credential); err != nil {
+			return nil, err
+		}
+		order.CompletedAuthorizations = append(order.CompletedAuthorizations, method)
+	}
+
+	if len(order.PendingAuthorizations()) == 0 {
+		nextRunAt := order.Occurrence(order.NextOccurrence)
+		order.State = domain.StandingOrderActive
+		order.NextRunAt = &nextRunAt
+	}
+	return order, service.save(ctx, order)
+}
+
+func (service *StandingOrderServiceImp) Cancel(id string, ctx context.Context) error {
+	order, err := service.loadOwned(id, ctx)
+	if err != nil {
+		return err
+	}
+
+	if isClosed(order) {
+		return alias.ErrMessageStandingOrderClosed
+	}
+
+	order.State = domain.StandingOrderCancelled
+	order.NextRunAt = nil
+	return service.save(ctx, order)
+}
+
+func (service *StandingOrderServiceImp) Get(id string, ctx context.Context) (*domain.StandingOrder, error) {
+	return service.loadOwned(id, ctx)
+}
+
+func (service *StandingOrderServiceImp) List(ctx context.Context) ([]domain.StandingOrder, error) {
+	userSession, err := service.userSession.GetFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.standingOrders.FindByUser(ctx, userSession.ID)
+}
+
+func (service *StandingOrderServiceImp) GetRuns(id string, ctx context.Context) ([]domain.StandingOrderRun, error) {
+	order, err := service.loadOwned(id, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return service.standingOrders.FindRuns(ctx, order.ID)
+}
+
+func (service *StandingOrderServiceImp) loadOwned(id string, ctx context.Context) (*domain.StandingOrder, error) {
+	userSession, err := service.userSession.GetFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := service.standingOrders.FindByID(ctx, id)
+	if err != nil || order.UserID != userSession.ID {
+		return nil, alias.ErrMessageStandingOrderNotFound
+	}
+	return order, nil
+}
+
+// save writes the changes made by the user, the progress of a run executing the order meanwhile is left alone
+func (service *StandingOrderServiceImp) save(ctx context.Context, order *domain.StandingOrder) error {
+	return service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		return service.standingOrders.Update(ctx, order)
+	})
+}
+
+// requestAuthorization evaluates the authorization policy against the mandate, its executions are pre-authorized
+// so the methods a single transfer of the same amount to the same destination would require are verified here
+func (service *StandingOrderServiceImp) requestAuthorization(order *domain.StandingOrder, authMethod string,
+	userSession domain.UserSession, ctx context.Context) error {
+	paid, err := service.transactions.HasPaidDestination(ctx, order.UserID, bankAlias.InternalBankCode, order.DestinationAccount)
+	if err != nil {
+		return err
+	}
+
+	level := service.authorizationPolicy.Evaluate(domain.AuthorizationRequest{
+		UserID:               order.UserID,
+		TransactionCode:      order.TransactionCode,
+		Amount:               order.Amount,
+		DestinationAccount:   order.DestinationAccount,
+		FirstTimeDestination: !paid,
+		RiskLevel:            userSession.RiskLevel,
+	})
+
+	required, err := trxServices.RequiredAuthorizations(level, authMethod, userSession)
+	if err != nil {
+		return err
+	}
+
+	order.State = domain.StandingOrderWaitAuthorization
+	order.AuthorizationMethod = required[0]
+	order.RequiredAuthorizations = required
+	order.CompletedAuthorizations = nil
+
+	for _, method := range required {
+		if method == domain.OtpAuthorization {
+			return service.otpCredentialManager.RequestNewOtp(order.UserID)
+		}
+	}
+	return nil
+}
+
+func (service *StandingOrderServiceImp) validateCredential(order *domain.StandingOrder, method domain.AuthorizationMethod,
+	credential string) error {
+	switch method {
+	case domain.OtpAuthorization:
+		return service.otpCredentialManager.Validate(order.UserID, credential)
+	case domain.PinAuthorization:
+		return service.pinCredentialManager.Validate(order.UserID, credential)
+	default:
+		return trxAlias.ErrMessageMethodNotSupported
+	}
+}
+
+// collectCredentials maps the credentials of the request to the pending methods, a single credential
+// without method name is used for the first pending method not covered by the named ones
+func collectCredentials(dto *dto.VerifyStandingOrderDto, pending []domain.AuthorizationMethod) map[domain.AuthorizationMethod]string {
+	credentials := map[domain.AuthorizationMethod]string{}
+	for name, credential := range dto.Credentials {
+		if method, ok := trxAlias.AuthMethods[name]; ok {
+			credentials[method] = credential
+		}
+	}
+
+	if dto.Credential == "" {
+		return credentials
+	}
+	for _, method := range pending {
+		if _, ok := credentials[method]; !ok {
+			credentials[method] = dto.Credential
+			break
+		}
+	}
+	return credentials
+}
+
+func validateCreation(dto *dto.CreateStandingOrderDto, userSession domain.UserSession) error {
+	if err := trxServices.ValidateTransactionCode(dto.TransactionCode); err != nil {
+		return err
+	}
+
+	if err := trxServices.ValidateAmount(dto.Amount); err != nil {
+		return err
+	}
+
+	if err := trxServices.CheckDestination(dto.DestinationAccount); err != nil {
+		return err
+	}
+
+	if err := trxServices.CheckValidMethod(dto.AuthMethod, userSession); err != nil {
+		return err
+	}
+
+	if _, ok := alias.Frequencies[dto.Frequency]; !ok {
+		return alias.ErrMessageFrequencyNotSupported
+	}
+
+	if !dto.StartDate.After(time.Now()) {
+		return alias.ErrMessageStartDateInPast
+	}
+
+	if dto.EndDate != nil && !dto.EndDate.After(dto.StartDate) {
+		return alias.ErrMessageEndDateBeforeStartDate
+	}
+	return nil
+}
+
+func isClosed(order *domain.StandingOrder) bool {
+	return order.State == domain.StandingOrderCancelled || order.State == domain.StandingOrderCompleted
+}
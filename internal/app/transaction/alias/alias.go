@@ -2,6 +2,8 @@ package alias
 
 import (
 	"errors"
+	"time"
+
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
 )
 
@@ -15,9 +17,56 @@ const (
 	Destination2      string  = "10002"
 	DefaultOtp        string  = "111111"
 	DefaultPin        string  = "123456"
+	Currency          string  = "IDR"
+	Unknown           string  = "Unknown"
 	WaitAuthorization string  = "WaitAuthorization"
 	Failed            string  = "Failed"
 	Success           string  = "Success"
+	Scheduled         string  = "Scheduled"
+	Processing        string  = "Processing"
+	SystemActor       string  = "system"
+	ClearingActor     string  = "clearing"
+)
+
+const (
+	// InterbankTransactionCode is the only transaction code allowed for transfers to other banks
+	InterbankTransactionCode string = TransactionCode2
+	// ClearingSettlementAccount is the core account interbank transfers are booked against once settled
+	ClearingSettlementAccount string = "99001"
+)
+
+const (
+	ScheduledTransactionPollInterval = time.Minute
+	// AuthorizationWindow is how long a transaction waiting for its authorization counts against the limits,
+	// an older one is considered abandoned and its limits are checked again when it is verified
+	AuthorizationWindow = 15 * time.Minute
+)
+
+const (
+	OutboxRelayInterval = 5 * time.Second
+	OutboxBatchSize     = 100
+	// OutboxLease is how long a relay owns a message it is delivering before another relay may retry it
+	OutboxLease = time.Minute
+	// OutboxRetryBackoff is the wait after the first failed delivery, it doubles on every following one
+	OutboxRetryBackoff = 10 * time.Second
+	// OutboxMaxAttempts is the number of deliveries after which the transaction is marked Failed
+	OutboxMaxAttempts = 6
+	// MetricsQueryTimeout bounds the queries run to expose a gauge on scrape
+	MetricsQueryTimeout = 2 * time.Second
+)
+
+// StepUpThresholds is the amount per transaction code from which an OTP is required instead of
+// any single credential, codes without an entry use DefaultStepUpThreshold
+var StepUpThresholds = map[string]float64{
+	TransactionCode1: 5000000,
+	TransactionCode2: 5000000,
+}
+
+// FeeWaiverLocation is the timezone in which the monthly free transfers reset
+var FeeWaiverLocation = time.FixedZone("WIB", 7*60*60)
+
+const (
+	DefaultStepUpThreshold float64 = 5000000
 )
 
 var AuthMethods = map[string]domain.AuthorizationMethod{
@@ -25,6 +74,11 @@ var AuthMethods = map[string]domain.AuthorizationMethod{
 	AuthMethod2: domain.PinAuthorization,
 }
 
+var AuthMethodNames = map[domain.AuthorizationMethod]string{
+	domain.OtpAuthorization: AuthMethod1,
+	domain.PinAuthorization: AuthMethod2,
+}
+
 var ValidTransactionCode = map[string]string{
 	TransactionCode1: TransactionCode1,
 	TransactionCode2: TransactionCode2,
@@ -36,19 +90,33 @@ var ValidDestination = map[string]string{
 }
 
 var TransactionState = map[domain.TransactionState]string{
-	domain.WaitAuthorization: WaitAuthorization,
-	domain.Success:           Success,
-	domain.Failed:            Failed,
+	domain.UnknownTransactionStatus: Unknown,
+	domain.WaitAuthorization:        WaitAuthorization,
+	domain.Success:                  Success,
+	domain.Failed:                   Failed,
+	domain.Scheduled:                Scheduled,
+	domain.Processing:               Processing,
 }
 
 var (
-	ErrMessageMethodNotConfigured     = errors.New("authorization method not configured")
-	ErrMessageMethodNotSupported      = errors.New("unsupported authorization method")
-	ErrMessageTransactionCodeNotFound = errors.New("transaction code not found")
-	ErrMessageAmountTooLow            = errors.New("amount does not reach the minimum transaction amount")
-	ErrMessageDestinationNotFound     = errors.New("destination account not found")
-	ErrMessageOtpNotConfigured        = errors.New("OTP not configured")
-	ErrMessagePinNotConfigured        = errors.New("PIN not configured")
-	ErrMessageInvalidCredential       = errors.New("invalid credential")
-	ErrMessageTransactionHadVerified  = errors.New("verification process already happened")
+	ErrMessageMethodNotConfigured      = errors.New("authorization method not configured")
+	ErrMessageMethodNotSupported       = errors.New("unsupported authorization method")
+	ErrMessageTransactionCodeNotFound  = errors.New("transaction code not found")
+	ErrMessageAmountTooLow             = errors.New("amount does not reach the minimum transaction amount")
+	ErrMessageDestinationNotFound      = errors.New("destination account not found")
+	ErrMessageOtpNotConfigured         = errors.New("OTP not configured")
+	ErrMessagePinNotConfigured         = errors.New("PIN not configured")
+	ErrMessageInvalidCredential        = errors.New("invalid credential")
+	ErrMessageTransactionHadVerified   = errors.New("verification process already happened")
+	ErrMessageExecutionDateInPast      = errors.New("execution date must be in the future")
+	ErrMessageDestinationNotActive     = errors.New("destination account is not active")
+	ErrMessageBeneficiaryNotFound      = errors.New("beneficiary not found")
+	ErrMessageAmbiguousDestination     = errors.New("either destination_account or beneficiary_id must be given, not both")
+	ErrMessageSourceAccountNotLinked   = errors.New("source account is not linked to the user")
+	ErrMessageTransactionNotPermitted  = errors.New("transaction code is not permitted on the source account")
+	ErrMessageCredentialRequired       = errors.New("credential is required")
+	ErrMessageTransactionNotFound      = errors.New("transaction not found")
+	ErrMessageTransactionNotProcessing = errors.New("transaction is not waiting for a settlement")
+	ErrMessageBankNotFound             = errors.New("bank not found")
+	ErrMessageInterbankCodeRequired    = errors.New("transfers to other banks require transaction code " + InterbankTransactionCode)
 )
@@ -3,13 +3,22 @@ package dto
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 )
 
 type CreateTransactionDto struct {
-	TransactionCode    string  `json:"transaction_code"`
-	Amount             float64 `json:"amount"`
-	DestinationAccount string  `json:"destination_account"`
-	AuthMethod         string  `json:"auth_method"`
+	TransactionCode     string     `json:"transaction_code" required:"true"`
+	Amount              float64    `json:"amount" required:"true"`
+	SourceAccount       string     `json:"source_account"`
+	DestinationAccount  string     `json:"destination_account"`
+	DestinationBankCode string     `json:"destination_bank_code"`
+	BeneficiaryID       string     `json:"beneficiary_id"`
+	AuthMethod          string     `json:"auth_method"`
+	ExecutionDate       *time.Time `json:"execution_date"`
+	ClientIP            string     `json:"-"`
+	// PreAuthorized is set by callers which hold an authorization given up front, such as standing
+	// orders, the authorization policy is not evaluated again for them
+	PreAuthorized bool `json:"-"`
 }
 
 func (dto *CreateTransactionDto) Bind(req *http.Request) error {
@@ -0,0 +1,18 @@
+package dto
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type QuoteTransactionDto struct {
+	TransactionCode string  `json:"transaction_code" required:"true"`
+	Amount          float64 `json:"amount" required:"true"`
+}
+
+func (dto *QuoteTransactionDto) Bind(req *http.Request) error {
+	if err := json.NewDecoder(req.Body).Decode(dto); err != nil {
+		return err
+	}
+	return nil
+}
@@ -5,7 +5,9 @@ import (
 )
 
 type VerifyTransactionDto struct {
-	ID         string             `json:"ID"`
-	Session    domain.UserSession `json:"session"`
-	Credential string             `json:"credential"`
+	ID          string             `json:"ID"`
+	Session     domain.UserSession `json:"session"`
+	Credential  string             `json:"credential"`
+	Credentials map[string]string  `json:"credentials"`
+	ClientIP    string             `json:"-"`
 }
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
@@ -10,34 +12,51 @@ import (
 	"github.com/go-chi/render"
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
 	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
 )
 
 type TransactionEndpoint struct {
 	userSessionHelper  domain.UserSessionHelper
 	transactionService services.TransactionCompositionService
+	limiter            *ratelimit.Limiter
+	authority          *jwt.Authority
+	trustForwardedFor  bool
 }
 
 func NewTransactionEndpoint(
 	userSessionHelper domain.UserSessionHelper,
-	transactionCompositionService services.TransactionCompositionService) *TransactionEndpoint {
+	transactionCompositionService services.TransactionCompositionService,
+	limiter *ratelimit.Limiter,
+	authority *jwt.Authority,
+	trustForwardedFor bool) *TransactionEndpoint {
 	return &TransactionEndpoint{
 		userSessionHelper:  userSessionHelper,
 		transactionService: transactionCompositionService,
+		limiter:            limiter,
+		authority:          authority,
+		trustForwardedFor:  trustForwardedFor,
 	}
 }
 
 func (transactionEndpoint *TransactionEndpoint) BindRoutes(r chi.Router) {
 	r.Group(func(r chi.Router) {
-		r = jwt.WrapChiRouterWithAuthorization(r)
+		r = transactionEndpoint.authority.WrapChiRouterWithAuthorization(r)
+		r.Use(transactionEndpoint.limiter.Limit(ratelimit.GroupTransaction))
 		r.Use(func(next http.Handler) http.Handler {
 			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 				w.Header().Set("Content-Type", "application/json")
 				next.ServeHTTP(w, r)
 			})
 		})
-		r.Post("/transaction", transactionEndpoint.HandleCreateTransaction)
-		r.Put("/transaction/{id}/verify", transactionEndpoint.HandleVerifyTransaction)
+		r.With(openapi.ValidateRequest(CreateTransactionOperation)).
+			Post("/transaction", transactionEndpoint.HandleCreateTransaction)
+		r.With(openapi.ValidateRequest(QuoteTransactionOperation)).
+			Post("/transaction/quote", transactionEndpoint.HandleQuoteTransaction)
+		r.With(openapi.ValidateRequest(VerifyTransactionOperation)).
+			Put("/transaction/{id}/verify", transactionEndpoint.HandleVerifyTransaction)
 		r.Get("/transaction/{id}", transactionEndpoint.HandleGetTransaction)
+		r.Get("/transaction/{id}/events", transactionEndpoint.HandleGetTransactionEvents)
 	})
 }
 
@@ -49,8 +68,14 @@ func (transactionEndpoint *TransactionEndpoint) HandleCreateTransaction(w http.R
 		render.JSON(w, r, &TransactionHandlerFailed{Message: err.Error()})
 		return
 	}
+	requestDto.ClientIP = ratelimit.ClientIP(r, transactionEndpoint.trustForwardedFor)
 
-	transactionID, err := transactionEndpoint.transactionService.CreateTransaction(requestDto, r.Context())
+	transaction, err := transactionEndpoint.transactionService.CreateTransaction(requestDto, r.Context())
+	var limited *ratelimit.LimitedError
+	if errors.As(err, &limited) {
+		ratelimit.RenderLimited(w, r, limited.RetryAfter)
+		return
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		render.JSON(w, r, &TransactionHandlerFailed{Message: err.Error()})
@@ -58,14 +83,43 @@ func (transactionEndpoint *TransactionEndpoint) HandleCreateTransaction(w http.R
 	}
 
 	w.WriteHeader(http.StatusCreated)
-	render.JSON(w, r, &CreateTransactionSuccess{transactionID})
+	render.JSON(w, r, &CreateTransactionSuccess{
+		TransactionID:       transaction.ID,
+		RequiredAuthMethods: authMethodNames(transaction.RequiredAuthorizations),
+	})
 	return
 }
 
+func (transactionEndpoint *TransactionEndpoint) HandleQuoteTransaction(w http.ResponseWriter, r *http.Request) {
+	requestDto := &dto.QuoteTransactionDto{}
+
+	if err := requestDto.Bind(r); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, &TransactionHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	quote, err := transactionEndpoint.transactionService.QuoteTransaction(requestDto, r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		render.JSON(w, r, &TransactionHandlerFailed{Message: err.Error()})
+		return
+	}
+
+	render.JSON(w, r, &QuoteTransactionSuccess{
+		TransactionCode:        quote.TransactionCode,
+		Amount:                 quote.Amount,
+		Fee:                    quote.Fee,
+		Total:                  quote.Total,
+		Currency:               quote.Currency,
+		FreeTransfersRemaining: quote.FreeTransfersRemaining,
+	})
+}
+
 func (transactionEndpoint *TransactionEndpoint) HandleVerifyTransaction(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	_, err := transactionEndpoint.transactionService.GetTransaction(id)
+	_, err := transactionEndpoint.transactionService.GetTransaction(id, r.Context())
 	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
 		render.JSON(w, r, &TransactionHandlerFailed{Message: "transaction not found"})
@@ -87,13 +141,20 @@ func (transactionEndpoint *TransactionEndpoint) HandleVerifyTransaction(w http.R
 		return
 	}
 
-	transaction := &dto.VerifyTransactionDto{
-		ID:         id,
-		Session:    userSession,
-		Credential: verifyTransaction.Credential,
+	verifyDto := &dto.VerifyTransactionDto{
+		ID:          id,
+		Session:     userSession,
+		Credential:  verifyTransaction.Credential,
+		Credentials: verifyTransaction.Credentials,
+		ClientIP:    ratelimit.ClientIP(r, transactionEndpoint.trustForwardedFor),
 	}
 
-	err = transactionEndpoint.transactionService.VerifyTransaction(transaction, r.Context())
+	transaction, err := transactionEndpoint.transactionService.VerifyTransaction(verifyDto, r.Context())
+	if err == alias.ErrMessageTransactionNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		render.JSON(w, r, &TransactionHandlerFailed{Message: err.Error()})
+		return
+	}
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		render.JSON(w, r, &TransactionHandlerFailed{Message: err.Error()})
@@ -101,19 +162,75 @@ func (transactionEndpoint *TransactionEndpoint) HandleVerifyTransaction(w http.R
 	}
 
 	w.WriteHeader(http.StatusAccepted)
-	render.JSON(w, r, &VerifyTransactionSuccess{transaction.ID})
+	render.JSON(w, r, &VerifyTransactionSuccess{
+		TransactionID:      transaction.ID,
+		State:              alias.TransactionState[transaction.State],
+		PendingAuthMethods: authMethodNames(transaction.PendingAuthorizations()),
+	})
 	return
 }
 
 func (transactionEndpoint *TransactionEndpoint) HandleGetTransaction(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
-	transactionReq, err := transactionEndpoint.transactionService.GetTransaction(id)
+	transactionReq, err := transactionEndpoint.transactionService.GetTransaction(id, r.Context())
+	if err == alias.ErrMessageTransactionNotFound || err == domain.ErrTransactionNotFound {
+		w.WriteHeader(http.StatusNotFound)
+		render.JSON(w, r, &TransactionHandlerFailed{Message: "transaction not found"})
+		return
+	}
 	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
 		render.JSON(w, r, &TransactionHandlerFailed{Message: err.Error()})
+		return
 	}
 
 	State := alias.TransactionState[transactionReq.State]
 
-	render.JSON(w, r, &GetTransactionSuccess{id, transactionReq.Amount,
-		transactionReq.DestinationAccount, State})
+	render.JSON(w, r, &GetTransactionSuccess{
+		ID:                 id,
+		Amount:             transactionReq.Amount,
+		Fee:                transactionReq.Fee,
+		Total:              transactionReq.Amount + transactionReq.Fee,
+		DestinationAccount: transactionReq.DestinationAccount,
+		DestinationBank:    transactionReq.DestinationBankCode,
+		DestinationName:    transactionReq.DestinationName,
+		State:              State,
+		ExecutionDate:      transactionReq.ExecutionDate,
+		FailureReason:      transactionReq.FailureReason,
+	})
+}
+
+func (transactionEndpoint *TransactionEndpoint) HandleGetTransactionEvents(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	events, err := transactionEndpoint.transactionService.GetTransactionEvents(id, r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		render.JSON(w, r, &TransactionHandlerFailed{Message: "transaction not found"})
+		return
+	}
+
+	response := &GetTransactionEventsSuccess{TransactionID: id, Events: []TransactionEventPayload{}}
+	for _, event := range events {
+		response.Events = append(response.Events, TransactionEventPayload{
+			FromState:     alias.TransactionState[event.FromState],
+			ToState:       alias.TransactionState[event.ToState],
+			ActorUserID:   event.ActorUserID,
+			AuthMethod:    alias.AuthMethodNames[event.AuthorizationMethod],
+			FailureReason: event.FailureReason,
+			ClientIP:      event.ClientIP,
+			Payload:       event.Payload,
+			CreatedAt:     event.CreatedAt,
+		})
+	}
+
+	render.JSON(w, r, response)
+}
+
+func authMethodNames(methods []domain.AuthorizationMethod) []string {
+	names := []string{}
+	for _, method := range methods {
+		names = append(names, alias.AuthMethodNames[method])
+	}
+	return names
 }
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
+)
+
+const openAPITag = "transaction"
+
+var (
+	unauthorized    = openapi.Response{Description: "The bearer token is missing or invalid"}
+	tooManyRequests = openapi.Response{Description: "The rate limit is exceeded, retry after the Retry-After header",
+		Body: openapi.ErrorResponse{}}
+	failed = openapi.Response{Description: "The request body is invalid or the transaction is refused",
+		Body: TransactionHandlerFailed{}}
+	notFound = openapi.Response{Description: "The transaction does not exist", Body: TransactionHandlerFailed{}}
+)
+
+var (
+	CreateTransactionOperation = openapi.Operation{
+		ID:      "createTransaction",
+		Method:  http.MethodPost,
+		Path:    "/transaction",
+		Summary: "Create a transaction, which waits for the returned authorization methods to be verified",
+		Tag:     openAPITag,
+		Secured: true,
+		Request: dto.CreateTransactionDto{},
+		Responses: map[int]openapi.Response{
+			http.StatusCreated:         {Description: "The transaction is created", Body: CreateTransactionSuccess{}},
+			http.StatusBadRequest:      failed,
+			http.StatusUnauthorized:    unauthorized,
+			http.StatusTooManyRequests: tooManyRequests,
+		},
+	}
+
+	QuoteTransactionOperation = openapi.Operation{
+		ID:      "quoteTransaction",
+		Method:  http.MethodPost,
+		Path:    "/transaction/quote",
+		Summary: "Quote the fee and total of a transaction without creating it",
+		Tag:     openAPITag,
+		Secured: true,
+		Request: dto.QuoteTransactionDto{},
+		Responses: map[int]openapi.Response{
+			http.StatusOK:              {Description: "The quote", Body: QuoteTransactionSuccess{}},
+			http.StatusBadRequest:      failed,
+			http.StatusUnauthorized:    unauthorized,
+			http.StatusTooManyRequests: tooManyRequests,
+		},
+	}
+
+	VerifyTransactionOperation = openapi.Operation{
+		ID:      "verifyTransaction",
+		Method:  http.MethodPut,
+		Path:    "/transaction/{id}/verify",
+		Summary: "Verify a credential of the transaction, it is executed once every authorization is verified",
+		Tag:     openAPITag,
+		Secured: true,
+		Request: VerifyTransactionRequest{},
+		Responses: map[int]openapi.Response{
+			http.StatusAccepted:        {Description: "The credential is verified", Body: VerifyTransactionSuccess{}},
+			http.StatusBadRequest:      failed,
+			http.StatusUnauthorized:    unauthorized,
+			http.StatusNotFound:        notFound,
+			http.StatusTooManyRequests: tooManyRequests,
+		},
+	}
+
+	GetTransactionOperation = openapi.Operation{
+		ID:      "getTransaction",
+		Method:  http.MethodGet,
+		Path:    "/transaction/{id}",
+		Summary: "Get a transaction",
+		Tag:     openAPITag,
+		Secured: true,
+		Responses: map[int]openapi.Response{
+			http.StatusOK:                  {Description: "The transaction", Body: GetTransactionSuccess{}},
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusNotFound:            notFound,
+			http.StatusTooManyRequests:     tooManyRequests,
+			http.StatusInternalServerError: {Description: "The transaction cannot be read", Body: TransactionHandlerFailed{}},
+		},
+	}
+
+	GetTransactionEventsOperation = openapi.Operation{
+		ID:      "getTransactionEvents",
+		Method:  http.MethodGet,
+		Path:    "/transaction/{id}/events",
+		Summary: "List the state changes of a transaction in the order they happened",
+		Tag:     openAPITag,
+		Secured: true,
+		Responses: map[int]openapi.Response{
+			http.StatusOK:                  {Description: "The events", Body: GetTransactionEventsSuccess{}},
+			http.StatusUnauthorized:        unauthorized,
+			http.StatusNotFound:            notFound,
+			http.StatusTooManyRequests:     tooManyRequests,
+			http.StatusInternalServerError: {Description: "The events cannot be read", Body: TransactionHandlerFailed{}},
+		},
+	}
+)
+
+// Operations documents the routes bound by BindRoutes
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		CreateTransactionOperation,
+		QuoteTransactionOperation,
+		VerifyTransactionOperation,
+		GetTransactionOperation,
+		GetTransactionEventsOperation,
+	}
+}
@@ -0,0 +1,50 @@
+package handler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/handler"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+)
+
+func TestOperations_Should_DocumentEveryBoundRoute(t *testing.T) {
+	router := chi.NewRouter()
+	handler.NewTransactionEndpoint(nil, nil, ratelimit.NewLimiter(nil, config.RateLimitConfig{}),
+		jwt.NewAuthority(config.Default().JWT), false).BindRoutes(router)
+
+	bound, err := openapi.BoundRoutes(router)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if documented := openapi.Routes(handler.Operations()); !reflect.DeepEqual(bound, documented) {
+		t.Fatalf("the specification drifted from the routes, bound %v but documented %v", bound, documented)
+	}
+}
+
+func TestOperations_Should_AcceptTheCreateTransactionBody_When_TheAuthMethodIsLeftToTheDefault(t *testing.T) {
+	var create openapi.Operation
+	for _, operation := range handler.Operations() {
+		if operation.ID == "createTransaction" {
+			create = operation
+		}
+	}
+	reached := false
+	validated := openapi.ValidateRequest(create)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+
+	body := `{"transaction_code": "T001", "amount": 3000, "destination_account": "10002"}`
+	recorder := httptest.NewRecorder()
+	validated.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transaction", strings.NewReader(body)))
+	if !reached {
+		t.Fatalf("a body without auth_method should reach the handler, got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
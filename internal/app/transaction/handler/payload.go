@@ -6,6 +6,7 @@ import (
 	"github.com/micro/go-micro/v3/errors"
 	"math/big"
 	"net/http"
+	"time"
 )
 
 type TransactionHandlerFailed struct {
@@ -44,7 +45,8 @@ func (payload *CreateTransactionRequest) Bind(req *http.Request) error {
 }
 
 type CreateTransactionSuccess struct {
-	TransactionID string `json:"transaction_id"`
+	TransactionID       string   `json:"transaction_id"`
+	RequiredAuthMethods []string `json:"required_auth_methods"`
 }
 
 func (resp *CreateTransactionSuccess) Render(w http.ResponseWriter, r *http.Request) error {
@@ -52,8 +54,18 @@ func (resp *CreateTransactionSuccess) Render(w http.ResponseWriter, r *http.Requ
 	return nil
 }
 
+type QuoteTransactionSuccess struct {
+	TransactionCode        string  `json:"transaction_code"`
+	Amount                 float64 `json:"amount"`
+	Fee                    float64 `json:"fee"`
+	Total                  float64 `json:"total"`
+	Currency               string  `json:"currency"`
+	FreeTransfersRemaining int     `json:"free_transfers_remaining"`
+}
+
 type VerifyTransactionRequest struct {
-	Credential string `json:"credential"`
+	Credential  string            `json:"credential"`
+	Credentials map[string]string `json:"credentials"`
 }
 
 func (payload *VerifyTransactionRequest) Bind(req *http.Request) error {
@@ -64,7 +76,9 @@ func (payload *VerifyTransactionRequest) Bind(req *http.Request) error {
 }
 
 type VerifyTransactionSuccess struct {
-	TransactionID string `json:"transaction_id"`
+	TransactionID      string   `json:"transaction_id"`
+	State              string   `json:"state"`
+	PendingAuthMethods []string `json:"pending_auth_methods"`
 }
 
 func (resp *VerifyTransactionSuccess) Render(w http.ResponseWriter, r *http.Request) error {
@@ -73,13 +87,40 @@ func (resp *VerifyTransactionSuccess) Render(w http.ResponseWriter, r *http.Requ
 }
 
 type GetTransactionSuccess struct {
-	ID                 string  `json:"id"`
-	Amount             float64 `json:"amount"`
-	DestinationAccount string  `json:"destination_account"`
-	State              string  `json:"state"`
+	ID                 string     `json:"id"`
+	Amount             float64    `json:"amount"`
+	Fee                float64    `json:"fee"`
+	Total              float64    `json:"total"`
+	DestinationAccount string     `json:"destination_account"`
+	DestinationBank    string     `json:"destination_bank_code"`
+	DestinationName    string     `json:"destination_name"`
+	State              string     `json:"state"`
+	ExecutionDate      *time.Time `json:"execution_date,omitempty"`
+	FailureReason      string     `json:"failure_reason,omitempty"`
 }
 
 func (resp *GetTransactionSuccess) Render(w http.ResponseWriter, r *http.Request) error {
 	w.WriteHeader(http.StatusOK)
 	return nil
 }
+
+type TransactionEventPayload struct {
+	FromState     string    `json:"from_state"`
+	ToState       string    `json:"to_state"`
+	ActorUserID   string    `json:"actor_user_id"`
+	AuthMethod    string    `json:"auth_method"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	ClientIP      string    `json:"client_ip"`
+	Payload       string    `json:"payload,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type GetTransactionEventsSuccess struct {
+	TransactionID string                    `json:"transaction_id"`
+	Events        []TransactionEventPayload `json:"events"`
+}
+
+func (resp *GetTransactionEventsSuccess) Render(w http.ResponseWriter, r *http.Request) error {
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
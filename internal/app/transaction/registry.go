@@ -1,43 +1,143 @@
 package transaction
 
 import (
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
 	"log"
 
 	"github.com/go-chi/chi"
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/handler"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/repository/postgres"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/pg"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
 	"go.uber.org/dig"
+	"go.uber.org/zap"
 )
 
 func Register(container *dig.Container) {
+	container.Provide(func(unitOfWork *pg.UnitOfWork) domain.UnitOfWork {
+		return unitOfWork
+	})
+
+	container.Provide(func(unitOfWork domain.UnitOfWork) domain.TransactionRepository {
+		return postgres.NewPostgresTransactionRepository(unitOfWork)
+	})
+
+	container.Provide(func() domain.OutboxRepository {
+		return postgres.NewPostgresOutboxRepository()
+	})
+
+	container.Provide(func() domain.SettlementCallbackRepository {
+		return postgres.NewPostgresSettlementCallbackRepository()
+	})
+
+	container.Provide(func() domain.AuthorizationPolicy {
+		return services.NewAuthorizationPolicy()
+	})
+
 	container.Provide(func(userSession domain.UserSessionHelper, otpCredentialManager domain.OtpCredentialManager,
-	) services.CreateTransactionService {
-		return services.NewCreateTransactionService(userSession, otpCredentialManager)
+		beneficiaryRepository domain.BeneficiaryRepository,
+		accountInformation domain.AccountInformationService,
+		userAccountRepository domain.UserAccountRepository,
+		limitService domain.TransactionLimitService,
+		authorizationPolicy domain.AuthorizationPolicy,
+		feeService services.TransactionFeeService,
+		bankDirectory domain.BankDirectory,
+		clearingGateway domain.ClearingGateway,
+		unitOfWork domain.UnitOfWork,
+		repository domain.TransactionRepository,
+		limiter *ratelimit.Limiter,
+		metrics *metrics.Metrics) services.CreateTransactionService {
+		return services.NewCreateTransactionService(userSession, otpCredentialManager, beneficiaryRepository, accountInformation,
+			userAccountRepository, limitService, authorizationPolicy, feeService, bankDirectory, clearingGateway, unitOfWork,
+			repository, limiter, metrics)
+	})
+
+	container.Provide(func(outboxRelay services.OutboxRelay, unitOfWork domain.UnitOfWork,
+		repository domain.TransactionRepository, outboxRepository domain.OutboxRepository,
+		callbackRepository domain.SettlementCallbackRepository, logger *zap.Logger) domain.ClearingSettlementListener {
+		return services.NewSettlementService(outboxRelay, unitOfWork, repository, outboxRepository, callbackRepository,
+			logger)
+	})
+
+	container.Provide(func(transactionService domain.TransactionService, unitOfWork domain.UnitOfWork,
+		repository domain.TransactionRepository, outboxRepository domain.OutboxRepository,
+		logger *zap.Logger) services.OutboxRelay {
+		return services.NewOutboxRelay(transactionService, unitOfWork, repository, outboxRepository, logger)
+	})
+
+	container.Provide(func(relay services.OutboxRelay) *services.OutboxRelayScheduler {
+		return services.NewOutboxRelayScheduler(relay, alias.OutboxRelayInterval)
+	})
+
+	container.Provide(func(outboxRelay services.OutboxRelay, clearingGateway domain.ClearingGateway,
+		unitOfWork domain.UnitOfWork, repository domain.TransactionRepository,
+		outboxRepository domain.OutboxRepository) services.TransactionRouter {
+		return services.NewTransactionRouter(outboxRelay, clearingGateway, unitOfWork, repository, outboxRepository)
 	})
 
 	container.Provide(func(userSession domain.UserSessionHelper, otpCredentialManager domain.OtpCredentialManager,
-		pinCredentialManager domain.PinCredentialManager) services.VerifyTransactionService {
-		return services.NewVerifyTransactionService(userSession, otpCredentialManager, pinCredentialManager)
+		pinCredentialManager domain.PinCredentialManager, limitService domain.TransactionLimitService,
+		router services.TransactionRouter, unitOfWork domain.UnitOfWork, repository domain.TransactionRepository,
+		metrics *metrics.Metrics) services.VerifyTransactionService {
+		return services.NewVerifyTransactionService(userSession, otpCredentialManager, pinCredentialManager,
+			limitService, router, unitOfWork, repository, metrics)
+	})
+
+	container.Provide(func(transactionInformation domain.TransactionInformationService,
+		repository domain.TransactionRepository) services.TransactionFeeService {
+		return services.NewTransactionFeeService(transactionInformation, repository)
 	})
 
 	container.Provide(func(
 		createTransactionService services.CreateTransactionService,
-		verifyTransactionService services.VerifyTransactionService) services.TransactionCompositionService {
-		return services.NewTransactionCompositionService(createTransactionService, verifyTransactionService)
+		verifyTransactionService services.VerifyTransactionService,
+		router services.TransactionRouter,
+		feeService services.TransactionFeeService,
+		userSession domain.UserSessionHelper,
+		repository domain.TransactionRepository) services.TransactionCompositionService {
+		return services.NewTracedTransactionCompositionService(services.NewTransactionCompositionService(
+			createTransactionService, verifyTransactionService, router, feeService, userSession, repository))
+	})
+
+	container.Provide(func(router services.TransactionRouter,
+		repository domain.TransactionRepository, logger *zap.Logger) services.ScheduledTransactionService {
+		return services.NewScheduledTransactionService(router, repository, logger)
+	})
+
+	container.Provide(func(scheduledTransactionService services.ScheduledTransactionService) *services.TransactionScheduler {
+		return services.NewTransactionScheduler(scheduledTransactionService, alias.ScheduledTransactionPollInterval)
 	})
 
 	container.Provide(func(
 		userSessionHelper domain.UserSessionHelper,
-		transactionService services.TransactionCompositionService) *handler.TransactionEndpoint {
-		return handler.NewTransactionEndpoint(userSessionHelper, transactionService)
+		transactionService services.TransactionCompositionService,
+		limiter *ratelimit.Limiter, authority *jwt.Authority, cfg *config.Config) *handler.TransactionEndpoint {
+		return handler.NewTransactionEndpoint(userSessionHelper, transactionService, limiter, authority,
+			cfg.RateLimit.TrustForwardedFor)
 	})
 }
 
 func Invoke(container *dig.Container) {
-	err := container.Invoke(func(router chi.Router, endpoint *handler.TransactionEndpoint) {
+	err := container.Invoke(func(router chi.Router, endpoint *handler.TransactionEndpoint,
+		scheduler *services.TransactionScheduler, outboxRelayScheduler *services.OutboxRelayScheduler,
+		serverLifecycle *lifecycle.Lifecycle, appMetrics *metrics.Metrics,
+		repository domain.TransactionRepository, logger *zap.Logger, document *openapi.Document) error {
 		log.Println("invoke transaction startup ...")
 		endpoint.BindRoutes(router)
+		document.Add(handler.Operations()...)
+		scheduler.Start()
+		outboxRelayScheduler.Start()
+		serverLifecycle.OnStop("scheduled transaction scheduler", scheduler.Shutdown)
+		serverLifecycle.OnStop("outbox relay scheduler", outboxRelayScheduler.Shutdown)
+		return appMetrics.RegisterGaugeFunc("transactions_pending_authorization",
+			"Transactions waiting for the user to authorize them.", services.PendingAuthorizationGauge(repository, logger))
 	})
 	if err != nil {
 		log.Fatal(err)
@@ -0,0 +1,31 @@
+package inmemory
+
+import (
+	"sync"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+// Datastore holds the rows of the in-memory repositories, they share it like the tables of one database
+type Datastore struct {
+	sync.Mutex
+	transactions map[string]domain.Transaction
+	events       []domain.TransactionEvent
+	outbox       map[string]domain.OutboxMessage
+	callbacks    map[string]domain.SettlementCallback
+}
+
+func NewDatastore() *Datastore {
+	return &Datastore{
+		transactions: map[string]domain.Transaction{},
+		outbox:       map[string]domain.OutboxMessage{},
+		callbacks:    map[string]domain.SettlementCallback{},
+	}
+}
+
+// clone copies the authorization slices so callers appending to them never write into the datastore
+func clone(transaction domain.Transaction) domain.Transaction {
+	transaction.RequiredAuthorizations = append([]domain.AuthorizationMethod(nil), transaction.RequiredAuthorizations...)
+	transaction.CompletedAuthorizations = append([]domain.AuthorizationMethod(nil), transaction.CompletedAuthorizations...)
+	return transaction
+}
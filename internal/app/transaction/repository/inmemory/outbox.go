@@ -0,0 +1,56 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type InMemoryOutboxRepository struct {
+	datastore *Datastore
+}
+
+func NewInMemoryOutboxRepository(datastore *Datastore) *InMemoryOutboxRepository {
+	return &InMemoryOutboxRepository{datastore: datastore}
+}
+
+func (inmem *InMemoryOutboxRepository) Claim(ctx context.Context, message *domain.OutboxMessage, now time.Time, leaseUntil time.Time) (bool, error) {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	stored, ok := inmem.datastore.outbox[message.ID]
+	if !ok || stored.ProcessedAt != nil || stored.NextAttemptAt.After(now) {
+		return false, nil
+	}
+	stored.Attempts++
+	stored.NextAttemptAt = leaseUntil
+	inmem.datastore.outbox[message.ID] = stored
+	*message = stored
+	return true, nil
+}
+
+func (inmem *InMemoryOutboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]domain.OutboxMessage, error) {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	var messages []domain.OutboxMessage
+	for _, message := range inmem.datastore.outbox {
+		if message.ProcessedAt == nil && !message.NextAttemptAt.After(now) {
+			messages = append(messages, message)
+		}
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+	if len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+func (inmem *InMemoryOutboxRepository) Save(ctx context.Context, message *domain.OutboxMessage) error {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	inmem.datastore.outbox[message.ID] = *message
+	return nil
+}
@@ -0,0 +1,36 @@
+package inmemory
+
+import (
+	"context"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type InMemorySettlementCallbackRepository struct {
+	datastore *Datastore
+}
+
+func NewInMemorySettlementCallbackRepository(datastore *Datastore) *InMemorySettlementCallbackRepository {
+	return &InMemorySettlementCallbackRepository{datastore: datastore}
+}
+
+func (inmem *InMemorySettlementCallbackRepository) ExistsForTransaction(ctx context.Context, transactionID string) (bool, error) {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	for _, callback := range inmem.datastore.callbacks {
+		if callback.TransactionID == transactionID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (inmem *InMemorySettlementCallbackRepository) Insert(ctx context.Context, callback *domain.SettlementCallback) error {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	if _, ok := inmem.datastore.callbacks[callback.ID]; ok {
+		return domain.ErrSettlementReplayed
+	}
+	inmem.datastore.callbacks[callback.ID] = *callback
+	return nil
+}
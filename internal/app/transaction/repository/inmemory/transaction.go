@@ -0,0 +1,220 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	bankAlias "github.com/tunaiku/mobilebanking/internal/app/bank/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+)
+
+type InMemoryTransactionRepository struct {
+	datastore *Datastore
+}
+
+func NewInMemoryTransactionRepository(datastore *Datastore) *InMemoryTransactionRepository {
+	return &InMemoryTransactionRepository{datastore: datastore}
+}
+
+func (inmem *InMemoryTransactionRepository) Save(ctx context.Context, transaction *domain.Transaction) error {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	inmem.datastore.transactions[transaction.ID] = clone(*transaction)
+	return nil
+}
+
+func (inmem *InMemoryTransactionRepository) FindByID(ctx context.Context, id string) (*domain.Transaction, error) {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	transaction, ok := inmem.datastore.transactions[id]
+	if !ok {
+		return nil, domain.ErrTransactionNotFound
+	}
+	transaction = clone(transaction)
+	return &transaction, nil
+}
+
+func (inmem *InMemoryTransactionRepository) FindByUser(ctx context.Context, userID string) ([]domain.Transaction, error) {
+	transactions := inmem.filter(func(transaction domain.Transaction) bool {
+		return transaction.UserID == userID
+	})
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].CreatedAt.After(transactions[j].CreatedAt)
+	})
+	return transactions, nil
+}
+
+func (inmem *InMemoryTransactionRepository) UpdateState(ctx context.Context, transaction *domain.Transaction,
+	event domain.TransactionEvent) error {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	stored, ok := inmem.datastore.transactions[transaction.ID]
+	if ok && event.FromState != domain.UnknownTransactionStatus && stored.State != event.FromState {
+		return domain.ErrTransactionStateChanged
+	}
+	inmem.datastore.transactions[transaction.ID] = clone(*transaction)
+	inmem.datastore.events = append(inmem.datastore.events, event)
+	return nil
+}
+
+func (inmem *InMemoryTransactionRepository) UpdateExternalReference(ctx context.Context, transaction *domain.Transaction) error {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	stored, ok := inmem.datastore.transactions[transaction.ID]
+	if !ok {
+		return domain.ErrTransactionNotFound
+	}
+	stored.ExternalReference = transaction.ExternalReference
+	inmem.datastore.transactions[transaction.ID] = stored
+	return nil
+}
+
+func (inmem *InMemoryTransactionRepository) FindByExternalReference(ctx context.Context, externalReference string) (*domain.Transaction, error) {
+	transactions := inmem.filter(func(transaction domain.Transaction) bool {
+		return transaction.ExternalReference == externalReference
+	})
+	if len(transactions) == 0 {
+		return nil, domain.ErrTransactionNotFound
+	}
+	return &transactions[0], nil
+}
+
+func (inmem *InMemoryTransactionRepository) FindDueScheduled(ctx context.Context, now time.Time) ([]domain.Transaction, error) {
+	transactions := inmem.filter(func(transaction domain.Transaction) bool {
+		return transaction.State == domain.Scheduled && transaction.ExecutionDate != nil && !transaction.ExecutionDate.After(now)
+	})
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].ExecutionDate.Before(*transactions[j].ExecutionDate)
+	})
+	return transactions, nil
+}
+
+func (inmem *InMemoryTransactionRepository) HasPaidDestination(ctx context.Context, userID string, bankCode string, accountNumber string) (bool, error) {
+	transactions := inmem.filter(func(transaction domain.Transaction) bool {
+		destinationBankCode := transaction.DestinationBankCode
+		if destinationBankCode == "" {
+			destinationBankCode = bankAlias.InternalBankCode
+		}
+		return transaction.UserID == userID && destinationBankCode == bankCode &&
+			transaction.DestinationAccount == accountNumber && transaction.State == domain.Success
+	})
+	return len(transactions) > 0, nil
+}
+
+func (inmem *InMemoryTransactionRepository) CountSince(ctx context.Context, userID string, transactionCode string, since time.Time) (int, error) {
+	transactions := inmem.filter(func(transaction domain.Transaction) bool {
+		return transaction.UserID == userID && transaction.TransactionCode == transactionCode &&
+			transaction.State != domain.Failed && !transaction.CreatedAt.Before(since)
+	})
+	return len(transactions), nil
+}
+
+func (inmem *InMemoryTransactionRepository) UsageSince(ctx context.Context, userID string, transactionCode string,
+	since time.Time) (float64, int, error) {
+	abandonedBefore := time.Now().UTC().Add(-alias.AuthorizationWindow)
+	transactions := inmem.filter(func(transaction domain.Transaction) bool {
+		return transaction.UserID == userID && transaction.TransactionCode == transactionCode &&
+			countsAgainstLimits(transaction, abandonedBefore) && !transaction.CreatedAt.Before(since)
+	})
+	var amount float64
+	for _, transaction := range transactions {
+		amount += transaction.Amount + transaction.Fee
+	}
+	return amount, len(transactions), nil
+}
+
+// LockUsage has nothing to lock, the in-memory repository is only used by tests running one flow at a time
+func (inmem *InMemoryTransactionRepository) LockUsage(ctx context.Context, userID string, transactionCode string) error {
+	return nil
+}
+
+func countsAgainstLimits(transaction domain.Transaction, abandonedBefore time.Time) bool {
+	switch transaction.State {
+	case domain.Success, domain.Processing, domain.Scheduled:
+		return true
+	case domain.WaitAuthorization:
+		return !transaction.CreatedAt.Before(abandonedBefore)
+	default:
+		return false
+	}
+}
+
+func (inmem *InMemoryTransactionRepository) NetMovementOfAccount(ctx context.Context, accountNumber string, from time.Time,
+	to time.Time) (float64, error) {
+	var net float64
+	for _, transaction := range inmem.successfulOfAccount(accountNumber, from, to) {
+		if transaction.SourceAccount == accountNumber {
+			net -= transaction.Amount + transaction.Fee
+		} else {
+			net += transaction.Amount
+		}
+	}
+	return net, nil
+}
+
+func (inmem *InMemoryTransactionRepository) ForEachOfAccount(ctx context.Context, accountNumber string, from time.Time,
+	to time.Time, fn func(transaction *domain.Transaction) error) error {
+	transactions := inmem.successfulOfAccount(accountNumber, from, to)
+	sort.Slice(transactions, func(i, j int) bool {
+		return transactions[i].BookedAt().Before(transactions[j].BookedAt())
+	})
+	for i := range transactions {
+		if err := fn(&transactions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (inmem *InMemoryTransactionRepository) FindExistingIDs(ctx context.Context, ids []string) ([]string, error) {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	var existing []string
+	for _, id := range ids {
+		if _, ok := inmem.datastore.transactions[id]; ok {
+			existing = append(existing, id)
+		}
+	}
+	return existing, nil
+}
+
+func (inmem *InMemoryTransactionRepository) CountByState(ctx context.Context, state domain.TransactionState) (int, error) {
+	transactions := inmem.filter(func(transaction domain.Transaction) bool {
+		return transaction.State == state
+	})
+	return len(transactions), nil
+}
+
+func (inmem *InMemoryTransactionRepository) FindEvents(ctx context.Context, transactionID string) ([]domain.TransactionEvent, error) {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	var events []domain.TransactionEvent
+	for _, event := range inmem.datastore.events {
+		if event.TransactionID == transactionID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (inmem *InMemoryTransactionRepository) successfulOfAccount(accountNumber string, from time.Time, to time.Time) []domain.Transaction {
+	return inmem.filter(func(transaction domain.Transaction) bool {
+		internal := transaction.DestinationBankCode == "" || transaction.DestinationBankCode == bankAlias.InternalBankCode
+		return transaction.State == domain.Success && !transaction.BookedAt().Before(from) && transaction.BookedAt().Before(to) &&
+			(transaction.SourceAccount == accountNumber || (internal && transaction.DestinationAccount == accountNumber))
+	})
+}
+
+func (inmem *InMemoryTransactionRepository) filter(match func(transaction domain.Transaction) bool) []domain.Transaction {
+	inmem.datastore.Lock()
+	defer inmem.datastore.Unlock()
+	var transactions []domain.Transaction
+	for _, transaction := range inmem.datastore.transactions {
+		if match(transaction) {
+			transactions = append(transactions, clone(transaction))
+		}
+	}
+	return transactions
+}
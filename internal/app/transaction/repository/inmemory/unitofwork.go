@@ -0,0 +1,18 @@
+package inmemory
+
+import (
+	"context"
+)
+
+// InMemoryUnitOfWork runs the function as is, the in-memory repositories apply every write immediately
+// and have nothing to roll back.
+type InMemoryUnitOfWork struct {
+}
+
+func NewInMemoryUnitOfWork() *InMemoryUnitOfWork {
+	return &InMemoryUnitOfWork{}
+}
+
+func (inmem *InMemoryUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	appPg "github.com/tunaiku/mobilebanking/internal/pkg/pg"
+)
+
+type PostgresOutboxRepository struct {
+}
+
+func NewPostgresOutboxRepository() *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{}
+}
+
+func (repo *PostgresOutboxRepository) Claim(ctx context.Context, message *domain.OutboxMessage, now time.Time, leaseUntil time.Time) (bool, error) {
+	result, err := appPg.FromContext(ctx).Query(message).
+		Set("attempts = attempts + 1").
+		Set("next_attempt_at = ?", leaseUntil).
+		WherePK().
+		Where("processed_at IS NULL").
+		Where("next_attempt_at <= ?", now).
+		Returning("*").
+		Update()
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+func (repo *PostgresOutboxRepository) FindPending(ctx context.Context, now time.Time, limit int) ([]domain.OutboxMessage, error) {
+	var messages []domain.OutboxMessage
+	err := appPg.FromContext(ctx).Query(&messages).
+		Where("processed_at IS NULL").
+		Where("next_attempt_at <= ?", now).
+		Order("created_at ASC").
+		Limit(limit).
+		Select()
+	return messages, err
+}
+
+func (repo *PostgresOutboxRepository) Save(ctx context.Context, message *domain.OutboxMessage) error {
+	return appPg.FromContext(ctx).Save(message)
+}
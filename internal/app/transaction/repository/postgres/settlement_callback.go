@@ -0,0 +1,36 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	appPg "github.com/tunaiku/mobilebanking/internal/pkg/pg"
+)
+
+const settlementCallbackKey = "settlement_callbacks_pkey"
+
+type PostgresSettlementCallbackRepository struct {
+}
+
+func NewPostgresSettlementCallbackRepository() *PostgresSettlementCallbackRepository {
+	return &PostgresSettlementCallbackRepository{}
+}
+
+func (repo *PostgresSettlementCallbackRepository) ExistsForTransaction(ctx context.Context, transactionID string) (bool, error) {
+	return appPg.FromContext(ctx).Query((*domain.SettlementCallback)(nil)).
+		Where("transaction_id = ?", transactionID).
+		Exists()
+}
+
+func (repo *PostgresSettlementCallbackRepository) Insert(ctx context.Context, callback *domain.SettlementCallback) error {
+	return replayAware(appPg.FromContext(ctx).Insert(callback))
+}
+
+// replayAware reports a duplicate settlement callback id raced in by a concurrent delivery as a replay
+func replayAware(err error) error {
+	if pgErr, ok := err.(pg.Error); ok && pgErr.IntegrityViolation() && pgErr.Field('n') == settlementCallbackKey {
+		return domain.ErrSettlementReplayed
+	}
+	return err
+}
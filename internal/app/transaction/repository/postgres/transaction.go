@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
+	bankAlias "github.com/tunaiku/mobilebanking/internal/app/bank/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	appPg "github.com/tunaiku/mobilebanking/internal/pkg/pg"
+)
+
+type PostgresTransactionRepository struct {
+	unitOfWork domain.UnitOfWork
+}
+
+func NewPostgresTransactionRepository(unitOfWork domain.UnitOfWork) *PostgresTransactionRepository {
+	return &PostgresTransactionRepository{unitOfWork: unitOfWork}
+}
+
+func (repo *PostgresTransactionRepository) Save(ctx context.Context, transaction *domain.Transaction) error {
+	return appPg.FromContext(ctx).Save(transaction)
+}
+
+func (repo *PostgresTransactionRepository) FindByID(ctx context.Context, id string) (*domain.Transaction, error) {
+	transaction := &domain.Transaction{ID: id}
+	return found(transaction, appPg.FromContext(ctx).Load(transaction))
+}
+
+func (repo *PostgresTransactionRepository) FindByUser(ctx context.Context, userID string) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := appPg.FromContext(ctx).Query(&transactions).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Select()
+	return transactions, err
+}
+
+func (repo *PostgresTransactionRepository) UpdateState(ctx context.Context, transaction *domain.Transaction,
+	event domain.TransactionEvent) error {
+	return repo.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if event.FromState == domain.UnknownTransactionStatus {
+			if err := appPg.FromContext(ctx).Save(transaction); err != nil {
+				return err
+			}
+			return appPg.FromContext(ctx).Insert(&event)
+		}
+
+		// the update only applies while the row is still in the state the change was made from, a concurrent
+		// change waits for the row lock and then finds the state moved on
+		result, err := appPg.FromContext(ctx).Query(transaction).
+			WherePK().
+			Where("state = ?", event.FromState).
+			Update()
+		if err != nil {
+			return err
+		}
+		if result.RowsAffected() == 0 {
+			return domain.ErrTransactionStateChanged
+		}
+		return appPg.FromContext(ctx).Insert(&event)
+	})
+}
+
+func (repo *PostgresTransactionRepository) UpdateExternalReference(ctx context.Context, transaction *domain.Transaction) error {
+	_, err := appPg.FromContext(ctx).Query(transaction).
+		Set("external_reference = ?external_reference").
+		WherePK().
+		Update()
+	return err
+}
+
+func (repo *PostgresTransactionRepository) FindByExternalReference(ctx context.Context, externalReference string) (*domain.Transaction, error) {
+	transaction := &domain.Transaction{}
+	err := appPg.FromContext(ctx).Query(transaction).
+		Where("external_reference = ?", externalReference).
+		Select()
+	return found(transaction, err)
+}
+
+func (repo *PostgresTransactionRepository) FindDueScheduled(ctx context.Context, now time.Time) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := appPg.FromContext(ctx).Query(&transactions).
+		Where("state = ?", domain.Scheduled).
+		Where("execution_date <= ?", now).
+		Order("execution_date ASC").
+		Select()
+	return transactions, err
+}
+
+func (repo *PostgresTransactionRepository) HasPaidDestination(ctx context.Context, userID string, bankCode string, accountNumber string) (bool, error) {
+	return appPg.FromContext(ctx).Query((*domain.Transaction)(nil)).
+		Where("user_id = ?", userID).
+		Where("coalesce(destination_bank_code, ?) = ?", bankAlias.InternalBankCode, bankCode).
+		Where("destination_account = ?", accountNumber).
+		Where("state = ?", domain.Success).
+		Exists()
+}
+
+func (repo *PostgresTransactionRepository) CountSince(ctx context.Context, userID string, transactionCode string, since time.Time) (int, error) {
+	return appPg.FromContext(ctx).Query((*domain.Transaction)(nil)).
+		Where("user_id = ?", userID).
+		Where("transaction_code = ?", transactionCode).
+		Where("state != ?", domain.Failed).
+		Where("created_at >= ?", since.UTC()).
+		Count()
+}
+
+func (repo *PostgresTransactionRepository) UsageSince(ctx context.Context, userID string, transactionCode string,
+	since time.Time) (float64, int, error) {
+	var amount float64
+	var count int
+	err := appPg.FromContext(ctx).Query((*domain.Transaction)(nil)).
+		ColumnExpr("coalesce(sum(amount + coalesce(fee, 0)), 0), count(*)").
+		Where("user_id = ?", userID).
+		Where("transaction_code = ?", transactionCode).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			return q.WhereIn("state IN (?)", []domain.TransactionState{domain.Success, domain.Processing, domain.Scheduled}).
+				WhereOrGroup(func(q *orm.Query) (*orm.Query, error) {
+					return q.Where("state = ?", domain.WaitAuthorization).
+						Where("created_at >= ?", time.Now().UTC().Add(-alias.AuthorizationWindow)), nil
+				}), nil
+		}).
+		Where("created_at >= ?", since.UTC()).
+		Select(pg.Scan(&amount, &count))
+	return amount, count, err
+}
+
+func (repo *PostgresTransactionRepository) LockUsage(ctx context.Context, userID string, transactionCode string) error {
+	return appPg.FromContext(ctx).Lock("usage:" + userID + ":" + transactionCode)
+}
+
+func (repo *PostgresTransactionRepository) NetMovementOfAccount(ctx context.Context, accountNumber string, from time.Time,
+	to time.Time) (float64, error) {
+	var net float64
+	err := successfulOfAccount(ctx, accountNumber, from, to).
+		ColumnExpr("coalesce(sum(case when destination_account = ? and coalesce(destination_bank_code, ?) = ? then amount else 0 end), 0) - "+
+			"coalesce(sum(case when source_account = ? then amount + fee else 0 end), 0)",
+			accountNumber, bankAlias.InternalBankCode, bankAlias.InternalBankCode, accountNumber).
+		Select(pg.Scan(&net))
+	return net, err
+}
+
+func (repo *PostgresTransactionRepository) ForEachOfAccount(ctx context.Context, accountNumber string, from time.Time,
+	to time.Time, fn func(transaction *domain.Transaction) error) error {
+	return successfulOfAccount(ctx, accountNumber, from, to).
+		OrderExpr("coalesce(execution_date, created_at) ASC").
+		ForEach(fn)
+}
+
+func (repo *PostgresTransactionRepository) FindExistingIDs(ctx context.Context, ids []string) ([]string, error) {
+	var existing []string
+	err := appPg.FromContext(ctx).Query((*domain.Transaction)(nil)).
+		Column("id").
+		Where("id IN (?)", pg.In(ids)).
+		Select(&existing)
+	return existing, err
+}
+
+func (repo *PostgresTransactionRepository) CountByState(ctx context.Context, state domain.TransactionState) (int, error) {
+	return appPg.FromContext(ctx).Query((*domain.Transaction)(nil)).
+		Where("state = ?", state).
+		Count()
+}
+
+func (repo *PostgresTransactionRepository) FindEvents(ctx context.Context, transactionID string) ([]domain.TransactionEvent, error) {
+	var events []domain.TransactionEvent
+	err := appPg.FromContext(ctx).Query(&events).
+		Where("transaction_id = ?", transactionID).
+		Order("created_at ASC").
+		Select()
+	return events, err
+}
+
+// successfulOfAccount selects the successful transactions debiting the account, or crediting it from
+// within the bank, created in [from, to)
+func successfulOfAccount(ctx context.Context, accountNumber string, from time.Time, to time.Time) *orm.Query {
+	return appPg.FromContext(ctx).Query((*domain.Transaction)(nil)).
+		Where("state = ?", domain.Success).
+		WhereGroup(func(q *orm.Query) (*orm.Query, error) {
+			return q.Where("source_account = ?", accountNumber).
+				WhereOrGroup(func(q *orm.Query) (*orm.Query, error) {
+					return q.Where("destination_account = ?", accountNumber).
+						Where("coalesce(destination_bank_code, ?) = ?", bankAlias.InternalBankCode, bankAlias.InternalBankCode), nil
+				}), nil
+		}).
+		Where("coalesce(execution_date, created_at) >= ?", from).
+		Where("coalesce(execution_date, created_at) < ?", to)
+}
+
+func found(transaction *domain.Transaction, err error) (*domain.Transaction, error) {
+	if err == pg.ErrNoRows {
+		return nil, domain.ErrTransactionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+)
+
+type authorizationRule func(request domain.AuthorizationRequest) (domain.AuthorizationLevel, bool)
+
+// RuleBasedAuthorizationPolicy evaluates its rules in order, the first matching rule decides the level
+// and a request matching none of them only needs a single credential.
+type RuleBasedAuthorizationPolicy struct {
+	rules []authorizationRule
+}
+
+func NewAuthorizationPolicy() domain.AuthorizationPolicy {
+	return &RuleBasedAuthorizationPolicy{rules: []authorizationRule{
+		highRiskUserRule,
+		firstTimeDestinationRule,
+		stepUpAmountRule,
+	}}
+}
+
+func (policy *RuleBasedAuthorizationPolicy) Evaluate(request domain.AuthorizationRequest) domain.AuthorizationLevel {
+	for _, rule := range policy.rules {
+		if level, ok := rule(request); ok {
+			return level
+		}
+	}
+	return domain.SingleFactorAuthorization
+}
+
+func highRiskUserRule(request domain.AuthorizationRequest) (domain.AuthorizationLevel, bool) {
+	return domain.MultiFactorAuthorization, request.RiskLevel == domain.HighRisk
+}
+
+func firstTimeDestinationRule(request domain.AuthorizationRequest) (domain.AuthorizationLevel, bool) {
+	return domain.MultiFactorAuthorization, request.FirstTimeDestination
+}
+
+func stepUpAmountRule(request domain.AuthorizationRequest) (domain.AuthorizationLevel, bool) {
+	threshold, ok := alias.StepUpThresholds[request.TransactionCode]
+	if !ok {
+		threshold = alias.DefaultStepUpThreshold
+	}
+	return domain.StepUpAuthorization, request.Amount >= threshold
+}
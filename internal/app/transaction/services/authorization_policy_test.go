@@ -0,0 +1,50 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+)
+
+func TestAuthorizationPolicy_Should_RequireASingleCredential_When_TheAmountIsBelowTheThreshold(t *testing.T) {
+	level := services.NewAuthorizationPolicy().Evaluate(domain.AuthorizationRequest{
+		TransactionCode: "T001",
+		Amount:          4999999,
+	})
+	if level != domain.SingleFactorAuthorization {
+		t.Fatalf("level should be single factor but got %d", level)
+	}
+}
+
+func TestAuthorizationPolicy_Should_RequireOtp_When_TheAmountReachesTheThreshold(t *testing.T) {
+	level := services.NewAuthorizationPolicy().Evaluate(domain.AuthorizationRequest{
+		TransactionCode: "T001",
+		Amount:          5000000,
+	})
+	if level != domain.StepUpAuthorization {
+		t.Fatalf("level should be step up but got %d", level)
+	}
+}
+
+func TestAuthorizationPolicy_Should_RequireBothCredentials_When_TheDestinationIsPaidForTheFirstTime(t *testing.T) {
+	level := services.NewAuthorizationPolicy().Evaluate(domain.AuthorizationRequest{
+		TransactionCode:      "T001",
+		Amount:               3000,
+		FirstTimeDestination: true,
+	})
+	if level != domain.MultiFactorAuthorization {
+		t.Fatalf("level should be multi factor but got %d", level)
+	}
+}
+
+func TestAuthorizationPolicy_Should_RequireBothCredentials_When_TheUserIsHighRisk(t *testing.T) {
+	level := services.NewAuthorizationPolicy().Evaluate(domain.AuthorizationRequest{
+		TransactionCode: "T001",
+		Amount:          3000,
+		RiskLevel:       domain.HighRisk,
+	})
+	if level != domain.MultiFactorAuthorization {
+		t.Fatalf("level should be multi factor but got %d", level)
+	}
+}
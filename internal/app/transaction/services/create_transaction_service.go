@@ -5,60 +5,251 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	bankAlias "github.com/tunaiku/mobilebanking/internal/app/bank/alias"
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
-	"github.com/tunaiku/mobilebanking/internal/pkg/pg"
+	"github.com/tunaiku/mobilebanking/internal/pkg/mask"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
 )
 
 type CreateTransactionService interface {
-	Invoke(dto *dto.CreateTransactionDto, ctx context.Context) (string, error)
+	Invoke(dto *dto.CreateTransactionDto, ctx context.Context) (*domain.Transaction, error)
+	InvokeWithSession(dto *dto.CreateTransactionDto, userSession domain.UserSession, ctx context.Context) (*domain.Transaction, error)
 }
 
 type CreateTransactionServiceImp struct {
-	userSession          domain.UserSessionHelper
-	otpCredentialManager domain.OtpCredentialManager
+	userSession           domain.UserSessionHelper
+	otpCredentialManager  domain.OtpCredentialManager
+	beneficiaryRepository domain.BeneficiaryRepository
+	accountInformation    domain.AccountInformationService
+	userAccountRepository domain.UserAccountRepository
+	limitService          domain.TransactionLimitService
+	authorizationPolicy   domain.AuthorizationPolicy
+	feeService            TransactionFeeService
+	bankDirectory         domain.BankDirectory
+	clearingGateway       domain.ClearingGateway
+	unitOfWork            domain.UnitOfWork
+	repository            domain.TransactionRepository
+	limiter               *ratelimit.Limiter
+	metrics               *metrics.Metrics
 }
 
 func NewCreateTransactionService(userSession domain.UserSessionHelper, otpCredentialManager domain.OtpCredentialManager,
-) CreateTransactionService {
-	return &CreateTransactionServiceImp{userSession: userSession, otpCredentialManager: otpCredentialManager}
+	beneficiaryRepository domain.BeneficiaryRepository, accountInformation domain.AccountInformationService,
+	userAccountRepository domain.UserAccountRepository, limitService domain.TransactionLimitService,
+	authorizationPolicy domain.AuthorizationPolicy, feeService TransactionFeeService, bankDirectory domain.BankDirectory,
+	clearingGateway domain.ClearingGateway, unitOfWork domain.UnitOfWork, repository domain.TransactionRepository,
+	limiter *ratelimit.Limiter, metrics *metrics.Metrics) CreateTransactionService {
+	return &CreateTransactionServiceImp{userSession: userSession, otpCredentialManager: otpCredentialManager,
+		beneficiaryRepository: beneficiaryRepository, accountInformation: accountInformation,
+		userAccountRepository: userAccountRepository, limitService: limitService,
+		authorizationPolicy: authorizationPolicy, feeService: feeService, bankDirectory: bankDirectory,
+		clearingGateway: clearingGateway, unitOfWork: unitOfWork, repository: repository, limiter: limiter,
+		metrics: metrics}
 }
 
-func (service *CreateTransactionServiceImp) Invoke(dto *dto.CreateTransactionDto, r context.Context) (string, error) {
+func (service *CreateTransactionServiceImp) Invoke(dto *dto.CreateTransactionDto, r context.Context) (*domain.Transaction, error) {
 	userSession, err := service.userSession.GetFromContext(r)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return service.InvokeWithSession(dto, userSession, r)
+}
+
+func (service *CreateTransactionServiceImp) InvokeWithSession(dto *dto.CreateTransactionDto, userSession domain.UserSession,
+	ctx context.Context) (*domain.Transaction, error) {
+	if err := service.resolveBeneficiary(dto, userSession, ctx); err != nil {
+		return nil, err
+	}
+
+	if dto.DestinationBankCode == "" {
+		dto.DestinationBankCode = bankAlias.InternalBankCode
 	}
 
 	if err := service.validate(dto, userSession); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	authMethod := domain.UnknownAuthorizationMethod
-	if dto.AuthMethod == alias.AuthMethod1 {
-		authMethod = domain.OtpAuthorization
-	} else {
-		authMethod = domain.PinAuthorization
+	linkedAccounts, err := service.userAccountRepository.FindAccountsByUser(userSession.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sourceAccount, err := service.resolveSourceAccount(dto, userSession, linkedAccounts, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := service.feeService.Quote(userSession.ID, dto.TransactionCode, dto.Amount, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationName, err := service.inquireDestination(dto, linkedAccounts, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the limits are checked in the unit of work saving the transaction so concurrent transfers of the user
+	// cannot both fit in the same remaining allowance, the OTP is only sent once it committed
+	var transaction *domain.Transaction
+	err = service.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		err := service.limitService.CheckLimit(userSession.ID, sourceAccount, dto.TransactionCode, dto.Amount,
+			quote.Fee, ctx)
+		if err != nil {
+			return err
+		}
+
+		transaction, err = service.save(dto, userSession, sourceAccount, destinationName, quote, ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := service.requestOtp(dto, transaction, userSession, ctx); err != nil {
+		return nil, err
+	}
+
+	service.metrics.TransactionCreated(transaction.TransactionCode, alias.AuthMethodNames[transaction.AuthorizationMethod])
+	return transaction, nil
+}
+
+// save records the transaction waiting for the authorizations the policy requires
+func (service *CreateTransactionServiceImp) save(dto *dto.CreateTransactionDto, userSession domain.UserSession,
+	sourceAccount string, destinationName string, quote domain.TransactionQuote,
+	ctx context.Context) (*domain.Transaction, error) {
+	requiredAuthorizations, err := service.requiredAuthorizations(dto, userSession, ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	transaction := &domain.Transaction{
-		ID:                  uuid.New().String(),
-		UserID:              userSession.ID,
-		State:               domain.WaitAuthorization,
-		AuthorizationMethod: authMethod,
-		TransactionCode:     dto.TransactionCode,
-		Amount:              dto.Amount,
-		SourceAccount:       userSession.AccountReference,
-		DestinationAccount:  dto.DestinationAccount,
-		CreatedAt:           time.Now().UTC(),
-	}
-
-	if err = pg.Wrap(nil).Save(transaction); err != nil {
+		ID:                     uuid.New().String(),
+		UserID:                 userSession.ID,
+		AuthorizationMethod:    requiredAuthorizations[0],
+		RequiredAuthorizations: requiredAuthorizations,
+		TransactionCode:        dto.TransactionCode,
+		Amount:                 dto.Amount,
+		Fee:                    quote.Fee,
+		SourceAccount:          sourceAccount,
+		DestinationAccount:     dto.DestinationAccount,
+		DestinationBankCode:    dto.DestinationBankCode,
+		DestinationName:        destinationName,
+		ExecutionDate:          dto.ExecutionDate,
+		CreatedAt:              time.Now().UTC(),
+	}
+
+	if dto.PreAuthorized {
+		transaction.CompletedAuthorizations = requiredAuthorizations
+	}
+
+	event := domain.TransactionEvent{ActorUserID: userSession.ID, ClientIP: dto.ClientIP}
+	err = changeTransactionState(ctx, service.unitOfWork, service.repository, transaction, domain.WaitAuthorization, event)
+	if err != nil {
+		return nil, err
+	}
+	return transaction, nil
+}
+
+// requestOtp sends the OTP of a saved transaction waiting for one, only the transfers sending an OTP count
+// against the OTP limit. A transaction refused by the limit is failed so it does not wait for an OTP never sent.
+func (service *CreateTransactionServiceImp) requestOtp(dto *dto.CreateTransactionDto, transaction *domain.Transaction,
+	userSession domain.UserSession, ctx context.Context) error {
+	if dto.PreAuthorized || !containsMethod(transaction.RequiredAuthorizations, domain.OtpAuthorization) {
+		return nil
+	}
+	if err := service.limiter.Take(ctx, ratelimit.GroupOtp, userSession.ID); err != nil {
+		event := domain.TransactionEvent{ActorUserID: userSession.ID, ClientIP: dto.ClientIP, FailureReason: err.Error()}
+		if stateErr := changeTransactionState(ctx, service.unitOfWork, service.repository, transaction, domain.Failed,
+			event); stateErr != nil {
+			return stateErr
+		}
+		return err
+	}
+	return service.otpCredentialManager.RequestNewOtp(userSession.ID)
+}
+
+// inquireDestination resolves the name of the recipient shown to the user, other people's names are masked,
+// accounts of other banks are inquired through the clearing
+func (service *CreateTransactionServiceImp) inquireDestination(dto *dto.CreateTransactionDto, linkedAccounts []domain.UserAccount,
+	ctx context.Context) (string, error) {
+	var inquiry domain.AccountInquiry
+	var err error
+	if isInterbank(dto.DestinationBankCode) {
+		inquiry, err = service.clearingGateway.InquireAccount(dto.DestinationBankCode, dto.DestinationAccount)
+	} else {
+		inquiry, err = service.accountInformation.InquireAccount(dto.DestinationAccount, ctx)
+	}
+	if err != nil {
+		return "", alias.ErrMessageDestinationNotFound
+	}
+
+	if inquiry.Status != domain.AccountActive {
+		return "", alias.ErrMessageDestinationNotActive
+	}
+
+	if !isInterbank(dto.DestinationBankCode) && isLinkedAccount(inquiry.AccountNumber, linkedAccounts) {
+		return inquiry.HolderName, nil
+	}
+	return mask.Name(inquiry.HolderName), nil
+}
+
+// resolveSourceAccount picks the account to debit, the user's primary account unless another linked
+// account is chosen, and checks the transaction code is permitted on it
+func (service *CreateTransactionServiceImp) resolveSourceAccount(dto *dto.CreateTransactionDto, userSession domain.UserSession,
+	linkedAccounts []domain.UserAccount, ctx context.Context) (string, error) {
+	sourceAccount := dto.SourceAccount
+	if sourceAccount == "" {
+		sourceAccount = userSession.AccountReference
+	} else if !isLinkedAccount(sourceAccount, linkedAccounts) {
+		return "", alias.ErrMessageSourceAccountNotLinked
+	}
+
+	privileges, err := service.accountInformation.GetTransactionPrivileges(sourceAccount, ctx)
+	if err != nil {
 		return "", err
 	}
 
-	return transaction.ID, nil
+	for _, code := range privileges.Codes {
+		if code == dto.TransactionCode {
+			return sourceAccount, nil
+		}
+	}
+	return "", alias.ErrMessageTransactionNotPermitted
+}
+
+func isLinkedAccount(accountNumber string, linkedAccounts []domain.UserAccount) bool {
+	for _, linked := range linkedAccounts {
+		if linked.AccountNumber == accountNumber {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveBeneficiary fills the destination account from the user's saved beneficiary
+func (service *CreateTransactionServiceImp) resolveBeneficiary(dto *dto.CreateTransactionDto, userSession domain.UserSession,
+	ctx context.Context) error {
+	if dto.BeneficiaryID == "" {
+		return nil
+	}
+
+	if dto.DestinationAccount != "" {
+		return alias.ErrMessageAmbiguousDestination
+	}
+
+	beneficiary, err := service.beneficiaryRepository.LoadBeneficiary(ctx, dto.BeneficiaryID)
+	if err != nil || beneficiary.UserID != userSession.ID {
+		return alias.ErrMessageBeneficiaryNotFound
+	}
+
+	dto.DestinationAccount = beneficiary.AccountNumber
+	dto.DestinationBankCode = beneficiary.BankCode
+	return nil
 }
 
 func (service *CreateTransactionServiceImp) validate(dto *dto.CreateTransactionDto, userSession domain.UserSession) error {
@@ -70,20 +261,105 @@ func (service *CreateTransactionServiceImp) validate(dto *dto.CreateTransactionD
 		return err
 	}
 
-	if err := CheckDestination(dto.DestinationAccount); err != nil {
+	if err := service.checkDestination(dto); err != nil {
 		return err
 	}
 
-	if err := CheckValidMethod(dto.AuthMethod, userSession); err != nil {
+	if err := ValidateExecutionDate(dto.ExecutionDate); err != nil {
 		return err
 	}
 
-	if err := service.RequestNewOtp(userSession); err != nil && dto.AuthMethod == alias.AuthMethod1 {
-		return err
+	if dto.AuthMethod != "" || dto.PreAuthorized {
+		if err := CheckValidMethod(dto.AuthMethod, userSession); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkDestination accepts the known internal destinations, or an account at a bank of the directory
+// when the interbank transaction code is used
+func (service *CreateTransactionServiceImp) checkDestination(dto *dto.CreateTransactionDto) error {
+	if !isInterbank(dto.DestinationBankCode) {
+		return CheckDestination(dto.DestinationAccount)
+	}
+
+	if _, err := service.bankDirectory.LoadBank(dto.DestinationBankCode); err != nil {
+		return alias.ErrMessageBankNotFound
+	}
+
+	if dto.TransactionCode != alias.InterbankTransactionCode {
+		return alias.ErrMessageInterbankCodeRequired
 	}
 	return nil
 }
 
+// requiredAuthorizations derives the methods the user has to verify from the authorization policy,
+// the method chosen by the client is only honoured when a single credential is enough
+func (service *CreateTransactionServiceImp) requiredAuthorizations(dto *dto.CreateTransactionDto,
+	userSession domain.UserSession, ctx context.Context) ([]domain.AuthorizationMethod, error) {
+	if dto.PreAuthorized {
+		return []domain.AuthorizationMethod{alias.AuthMethods[dto.AuthMethod]}, nil
+	}
+
+	paid, err := service.repository.HasPaidDestination(ctx, userSession.ID, dto.DestinationBankCode, dto.DestinationAccount)
+	if err != nil {
+		return nil, err
+	}
+
+	level := service.authorizationPolicy.Evaluate(domain.AuthorizationRequest{
+		UserID:               userSession.ID,
+		TransactionCode:      dto.TransactionCode,
+		Amount:               dto.Amount,
+		DestinationAccount:   dto.DestinationAccount,
+		FirstTimeDestination: !paid,
+		RiskLevel:            userSession.RiskLevel,
+	})
+
+	return RequiredAuthorizations(level, dto.AuthMethod, userSession)
+}
+
+// RequiredAuthorizations maps the level decided by the authorization policy to the methods the user has to
+// verify, the method chosen by the user only applies when a single credential is enough
+func RequiredAuthorizations(level domain.AuthorizationLevel, authMethod string,
+	userSession domain.UserSession) ([]domain.AuthorizationMethod, error) {
+	var required []domain.AuthorizationMethod
+	switch level {
+	case domain.MultiFactorAuthorization:
+		required = []domain.AuthorizationMethod{domain.PinAuthorization, domain.OtpAuthorization}
+	case domain.StepUpAuthorization:
+		required = []domain.AuthorizationMethod{domain.OtpAuthorization}
+	default:
+		required = []domain.AuthorizationMethod{defaultAuthorizationMethod(authMethod, userSession)}
+	}
+
+	for _, method := range required {
+		if err := CheckValidMethod(alias.AuthMethodNames[method], userSession); err != nil {
+			return nil, err
+		}
+	}
+	return required, nil
+}
+
+func defaultAuthorizationMethod(authMethod string, userSession domain.UserSession) domain.AuthorizationMethod {
+	if method, ok := alias.AuthMethods[authMethod]; ok {
+		return method
+	}
+	if userSession.ConfiguredTransactionCredential.IsPinConfigured() {
+		return domain.PinAuthorization
+	}
+	return domain.OtpAuthorization
+}
+
+func containsMethod(methods []domain.AuthorizationMethod, method domain.AuthorizationMethod) bool {
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
 func ValidateTransactionCode(transactionCode string) error {
 	if _, ok := alias.ValidTransactionCode[transactionCode]; ok {
 		return nil
@@ -99,6 +375,13 @@ func ValidateAmount(amount float64) error {
 	return nil
 }
 
+func ValidateExecutionDate(executionDate *time.Time) error {
+	if executionDate != nil && !executionDate.After(time.Now()) {
+		return alias.ErrMessageExecutionDateInPast
+	}
+	return nil
+}
+
 func CheckDestination(destination string) error {
 	if _, ok := alias.ValidDestination[destination]; ok {
 		return nil
@@ -122,9 +405,3 @@ func CheckValidMethod(authMethod string, user domain.UserSession) error {
 
 	return nil
 }
-func (service *CreateTransactionServiceImp) RequestNewOtp(userSession domain.UserSession) error {
-	if !userSession.ConfiguredTransactionCredential.IsOtpConfigured() {
-		return alias.ErrMessageOtpNotConfigured
-	}
-	return nil
-}
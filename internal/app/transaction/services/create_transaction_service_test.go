@@ -0,0 +1,280 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	bankAlias "github.com/tunaiku/mobilebanking/internal/app/bank/alias"
+	bankInmemory "github.com/tunaiku/mobilebanking/internal/app/bank/repository/inmemory"
+	beneficiaryInmemory "github.com/tunaiku/mobilebanking/internal/app/beneficiary/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	limitAlias "github.com/tunaiku/mobilebanking/internal/app/limit/alias"
+	limitInmemory "github.com/tunaiku/mobilebanking/internal/app/limit/repository/inmemory"
+	limitServices "github.com/tunaiku/mobilebanking/internal/app/limit/services"
+	"github.com/tunaiku/mobilebanking/internal/app/savings/service/fake"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+	userInmemory "github.com/tunaiku/mobilebanking/internal/app/user/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+)
+
+// linkedUserID owns the accounts 10001 and 20001 in the in-memory user account repository
+const linkedUserID = "fc55e3a8-c0fb-40c7-ab8a-9cda3fca40d4"
+
+type stubOtpCredentialManager struct {
+	requested []string
+}
+
+func (stub *stubOtpCredentialManager) Validate(userID string, credential string) error {
+	return nil
+}
+
+func (stub *stubOtpCredentialManager) RequestNewOtp(userID string) error {
+	stub.requested = append(stub.requested, userID)
+	return nil
+}
+
+// paidDestination books a past transfer of the linked user to the destination of transferOf so it is not paid
+// for the first time, which would require both credentials
+func paidDestination(transactions domain.TransactionRepository) {
+	transactions.Save(context.Background(), &domain.Transaction{
+		ID:                  "trx-paid",
+		UserID:              linkedUserID,
+		TransactionCode:     alias.TransactionCode1,
+		Amount:              3000,
+		DestinationBankCode: bankAlias.InternalBankCode,
+		DestinationAccount:  alias.Destination2,
+		State:               domain.Success,
+		CreatedAt:           time.Now().UTC().AddDate(0, 0, -7),
+	})
+}
+
+type createFixture struct {
+	transactions  domain.TransactionRepository
+	beneficiaries domain.BeneficiaryRepository
+	otp           *stubOtpCredentialManager
+	service       services.CreateTransactionService
+}
+
+func newCreateFixture() createFixture {
+	fixture := createFixture{
+		transactions:  inmemory.NewInMemoryTransactionRepository(inmemory.NewDatastore()),
+		beneficiaries: beneficiaryInmemory.NewInMemoryBeneficiaryRepository(),
+		otp:           &stubOtpCredentialManager{},
+	}
+	paidDestination(fixture.transactions)
+	accountInformation := fake.NewFakeAccountInformationService()
+	limitService := limitServices.NewTransactionLimitService(
+		limitInmemory.NewInMemoryTransactionLimitRepository(limitAlias.DefaultLimits), accountInformation,
+		fixture.transactions)
+	feeService := services.NewTransactionFeeService(fake.NewFakeTransactionInformationService(), fixture.transactions)
+	limiter := ratelimit.NewLimiter(ratelimit.NewInMemoryStore(), config.RateLimitConfig{
+		Groups: map[string]config.RateLimitGroup{ratelimit.GroupOtp: {Requests: 1, Period: time.Hour}},
+	})
+	fixture.service = services.NewCreateTransactionService(&stubUserSessionHelper{}, fixture.otp, fixture.beneficiaries,
+		accountInformation, userInmemory.NewInMemoryUserAccountRepository(), limitService,
+		services.NewAuthorizationPolicy(), feeService, bankInmemory.NewInMemoryBankDirectory(bankAlias.Banks),
+		&stubClearingGateway{}, inmemory.NewInMemoryUnitOfWork(), fixture.transactions, limiter, metrics.New())
+	return fixture
+}
+
+func linkedUserSession() domain.UserSession {
+	user := pinUser(linkedUserID)
+	user.AccountReference = "10001"
+	user.ConfiguredTransactionCredential.Otp = &domain.OtpCredential{PhoneNumber: "08123456789"}
+	return domain.UserSession{User: user}
+}
+
+func transferOf(amount float64, authMethod string) *dto.CreateTransactionDto {
+	return &dto.CreateTransactionDto{
+		TransactionCode:    alias.TransactionCode1,
+		Amount:             amount,
+		DestinationAccount: alias.Destination2,
+		AuthMethod:         authMethod,
+	}
+}
+
+func TestCreateTransactionService_Should_SaveTheTransactionWaitingForAuthorization_When_TheRequestIsValid(t *testing.T) {
+	fixture := newCreateFixture()
+
+	transaction, err := fixture.service.InvokeWithSession(transferOf(3000, alias.AuthMethod2), linkedUserSession(),
+		context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := fixture.transactions.FindByID(context.Background(), transaction.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.State != domain.WaitAuthorization || saved.UserID != linkedUserID || saved.SourceAccount != "10001" {
+		t.Fatalf("the transaction should wait for the authorization of its owner, got %+v", saved)
+	}
+}
+
+func TestCreateTransactionService_Should_RefuseTheTransaction_When_TheDailyAmountIsExceeded(t *testing.T) {
+	fixture := newCreateFixture()
+	err := fixture.transactions.Save(context.Background(), &domain.Transaction{
+		ID:              "trx-1",
+		UserID:          linkedUserID,
+		TransactionCode: alias.TransactionCode1,
+		Amount:          45000000,
+		State:           domain.Success,
+		CreatedAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = fixture.service.InvokeWithSession(transferOf(10000000, alias.AuthMethod2), linkedUserSession(),
+		context.Background())
+	if !errors.Is(err, limitAlias.ErrMessageDailyAmountLimitExceeded) {
+		t.Fatalf("err should be `limitAlias.ErrMessageDailyAmountLimitExceeded` but was %v", err)
+	}
+}
+
+func TestCreateTransactionService_Should_LimitTheOtpSent_When_TheTransfersAreAuthorizedByOtp(t *testing.T) {
+	fixture := newCreateFixture()
+
+	if _, err := fixture.service.InvokeWithSession(transferOf(3000, alias.AuthMethod1), linkedUserSession(), context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	_, err := fixture.service.InvokeWithSession(transferOf(3000, alias.AuthMethod1), linkedUserSession(), context.Background())
+	var limited *ratelimit.LimitedError
+	if !errors.As(err, &limited) {
+		t.Fatalf("err should be a `*ratelimit.LimitedError` but was %v", err)
+	}
+	if len(fixture.otp.requested) != 1 {
+		t.Fatalf("a single OTP should be sent but %d were", len(fixture.otp.requested))
+	}
+}
+
+func TestCreateTransactionService_Should_NotCountAgainstTheOtpLimit_When_TheTransfersAreAuthorizedByPin(t *testing.T) {
+	fixture := newCreateFixture()
+
+	for i := 0; i < 3; i++ {
+		if _, err := fixture.service.InvokeWithSession(transferOf(3000, alias.AuthMethod2), linkedUserSession(), context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(fixture.otp.requested) != 0 {
+		t.Fatalf("no OTP should be sent but %d were", len(fixture.otp.requested))
+	}
+}
+
+func TestCreateTransactionService_Should_NotCountTheAbandonedAuthorizations_When_TheDailyAmountIsChecked(t *testing.T) {
+	fixture := newCreateFixture()
+	err := fixture.transactions.Save(context.Background(), &domain.Transaction{
+		ID:              "trx-1",
+		UserID:          linkedUserID,
+		TransactionCode: alias.TransactionCode1,
+		Amount:          45000000,
+		State:           domain.WaitAuthorization,
+		CreatedAt:       time.Now().UTC().Add(-alias.AuthorizationWindow - time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = fixture.service.InvokeWithSession(transferOf(10000000, alias.AuthMethod2), linkedUserSession(),
+		context.Background())
+	if err != nil {
+		t.Fatalf("an abandoned authorization should not count against the daily amount but got %v", err)
+	}
+}
+
+func TestCreateTransactionService_Should_CountTheFee_When_ThePerTransactionLimitIsChecked(t *testing.T) {
+	fixture := newCreateFixture()
+	for _, id := range []string{"trx-1", "trx-2"} {
+		err := fixture.transactions.Save(context.Background(), &domain.Transaction{
+			ID:              id,
+			UserID:          linkedUserID,
+			TransactionCode: alias.TransactionCode2,
+			Amount:          3000,
+			State:           domain.Success,
+			CreatedAt:       time.Now().UTC(),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the monthly free transfers are used up, the 6500 fee takes the transfer over the 10000000 cap
+	transfer := transferOf(9999000, alias.AuthMethod2)
+	transfer.TransactionCode = alias.TransactionCode2
+	_, err := fixture.service.InvokeWithSession(transfer, linkedUserSession(), context.Background())
+	if !errors.Is(err, limitAlias.ErrMessagePerTransactionLimitExceeded) {
+		t.Fatalf("err should be `limitAlias.ErrMessagePerTransactionLimitExceeded` but was %v", err)
+	}
+}
+
+func TestCreateTransactionService_Should_NotSendTheOtp_When_TheLimitsRefuseTheTransfer(t *testing.T) {
+	fixture := newCreateFixture()
+	err := fixture.transactions.Save(context.Background(), &domain.Transaction{
+		ID:              "trx-1",
+		UserID:          linkedUserID,
+		TransactionCode: alias.TransactionCode1,
+		Amount:          45000000,
+		State:           domain.Success,
+		CreatedAt:       time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = fixture.service.InvokeWithSession(transferOf(10000000, alias.AuthMethod1), linkedUserSession(),
+		context.Background())
+	if !errors.Is(err, limitAlias.ErrMessageDailyAmountLimitExceeded) {
+		t.Fatalf("err should be `limitAlias.ErrMessageDailyAmountLimitExceeded` but was %v", err)
+	}
+	if len(fixture.otp.requested) != 0 {
+		t.Fatalf("no OTP should be sent but %d were", len(fixture.otp.requested))
+	}
+	// the OTP limit allows a single OTP an hour, it is still available
+	if _, err := fixture.service.InvokeWithSession(transferOf(3000, alias.AuthMethod1), linkedUserSession(), context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateTransactionService_Should_TransferToTheSavedAccount_When_TheUserPicksABeneficiary(t *testing.T) {
+	fixture := newCreateFixture()
+	err := fixture.beneficiaries.Save(context.Background(), &domain.Beneficiary{ID: "beneficiary-1", UserID: linkedUserID,
+		AccountNumber: alias.Destination2, BankCode: bankAlias.InternalBankCode, Nickname: "Mom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer := transferOf(3000, alias.AuthMethod2)
+	transfer.DestinationAccount = ""
+	transfer.BeneficiaryID = "beneficiary-1"
+
+	transaction, err := fixture.service.InvokeWithSession(transfer, linkedUserSession(), context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.DestinationAccount != alias.Destination2 || transaction.DestinationBankCode != bankAlias.InternalBankCode {
+		t.Fatalf("the transfer should go to the account of the beneficiary, got %+v", transaction)
+	}
+}
+
+func TestCreateTransactionService_Should_RefuseTheBeneficiary_When_ItBelongsToAnotherUser(t *testing.T) {
+	fixture := newCreateFixture()
+	err := fixture.beneficiaries.Save(context.Background(), &domain.Beneficiary{ID: "beneficiary-1", UserID: "another-user",
+		AccountNumber: alias.Destination2, BankCode: bankAlias.InternalBankCode, Nickname: "Mom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	transfer := transferOf(3000, alias.AuthMethod2)
+	transfer.DestinationAccount = ""
+	transfer.BeneficiaryID = "beneficiary-1"
+
+	_, err = fixture.service.InvokeWithSession(transfer, linkedUserSession(), context.Background())
+	if err != alias.ErrMessageBeneficiaryNotFound {
+		t.Fatalf("err should be `alias.ErrMessageBeneficiaryNotFound` but was %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+)
+
+type TransactionFeeService interface {
+	Quote(userID string, transactionCode string, amount float64, ctx context.Context) (domain.TransactionQuote, error)
+}
+
+type TransactionFeeServiceImp struct {
+	transactionInformation domain.TransactionInformationService
+	repository             domain.TransactionRepository
+}
+
+func NewTransactionFeeService(transactionInformation domain.TransactionInformationService,
+	repository domain.TransactionRepository) TransactionFeeService {
+	return &TransactionFeeServiceImp{transactionInformation: transactionInformation, repository: repository}
+}
+
+// Quote applies the fee rule of the transaction code, the transfers the user already made this month
+// are counted against the monthly waiver.
+func (service *TransactionFeeServiceImp) Quote(userID string, transactionCode string, amount float64,
+	ctx context.Context) (domain.TransactionQuote, error) {
+	detail, err := service.transactionInformation.FindTransactionDetailByCode(transactionCode, ctx)
+	if err != nil {
+		return domain.TransactionQuote{}, err
+	}
+
+	transfers, err := service.repository.CountSince(ctx, userID, transactionCode, startOfMonth(time.Now()))
+	if err != nil {
+		return domain.TransactionQuote{}, err
+	}
+
+	fee := detail.Fee.Calculate(amount, transfers)
+	freeTransfersRemaining := detail.Fee.FreeTransfersPerMonth - transfers
+	if freeTransfersRemaining < 0 {
+		freeTransfersRemaining = 0
+	}
+
+	return domain.TransactionQuote{
+		TransactionCode:        transactionCode,
+		Amount:                 amount,
+		Fee:                    fee,
+		Total:                  amount + fee,
+		Currency:               alias.Currency,
+		FreeTransfersRemaining: freeTransfersRemaining,
+	}, nil
+}
+
+// startOfMonth is when the monthly free transfers reset, in the fee waiver timezone
+func startOfMonth(now time.Time) time.Time {
+	local := now.In(alias.FeeWaiverLocation)
+	return time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, alias.FeeWaiverLocation)
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+	"math"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"go.uber.org/zap"
+)
+
+// PendingAuthorizationGauge counts the transactions in WaitAuthorization on every scrape, a failed count
+// is exposed as NaN rather than a misleading zero
+func PendingAuthorizationGauge(repository domain.TransactionRepository, logger *zap.Logger) func() float64 {
+	return func() float64 {
+		ctx, cancel := context.WithTimeout(context.Background(), alias.MetricsQueryTimeout)
+		defer cancel()
+		count, err := repository.CountByState(ctx, domain.WaitAuthorization)
+		if err != nil {
+			logger.Error("counting pending authorizations failed", zap.Error(err))
+			return math.NaN()
+		}
+		return float64(count)
+	}
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"github.com/tunaiku/mobilebanking/internal/pkg/scheduler"
+	"go.uber.org/zap"
+)
+
+// OutboxRelay delivers the outbox messages to the core. A delivery leases the message first so concurrent
+// relays never send it together, the transaction becomes Success once the core booked it and Failed once the
+// core rejected it or alias.OutboxMaxAttempts deliveries failed, in the same database transaction as the
+// message is marked processed.
+type OutboxRelay interface {
+	Deliver(transaction *domain.Transaction, message *domain.OutboxMessage, ctx context.Context) error
+	RelayPending(now time.Time, ctx context.Context) error
+}
+
+type OutboxRelayImp struct {
+	transactionService    domain.TransactionService
+	unitOfWork            domain.UnitOfWork
+	transactionRepository domain.TransactionRepository
+	outboxRepository      domain.OutboxRepository
+	logger                *zap.Logger
+}
+
+func NewOutboxRelay(transactionService domain.TransactionService, unitOfWork domain.UnitOfWork,
+	transactionRepository domain.TransactionRepository, outboxRepository domain.OutboxRepository,
+	logger *zap.Logger) OutboxRelay {
+	return &OutboxRelayImp{transactionService: transactionService, unitOfWork: unitOfWork,
+		transactionRepository: transactionRepository, outboxRepository: outboxRepository, logger: logger}
+}
+
+func (relay *OutboxRelayImp) Deliver(transaction *domain.Transaction, message *domain.OutboxMessage, ctx context.Context) error {
+	now := time.Now().UTC()
+	claimed, err := relay.outboxRepository.Claim(ctx, message, now, now.Add(alias.OutboxLease))
+	if err != nil || !claimed {
+		return err
+	}
+
+	var creation domain.TransactionCreation
+	if err := json.Unmarshal([]byte(message.Payload), &creation); err != nil {
+		return err
+	}
+
+	event := domain.TransactionEvent{ActorUserID: alias.SystemActor}
+	deliveryErr := relay.transactionService.CreateTransaction(creation, ctx)
+	if deliveryErr == nil {
+		message.ProcessedAt = &now
+		return relay.changeState(ctx, transaction, domain.Success, event, message)
+	}
+
+	message.LastError = deliveryErr.Error()
+	var rejected *domain.TransactionRejectedError
+	if errors.As(deliveryErr, &rejected) || message.Attempts >= alias.OutboxMaxAttempts {
+		message.ProcessedAt = &now
+		event.FailureReason = deliveryErr.Error()
+		if err := relay.changeState(ctx, transaction, domain.Failed, event, message); err != nil {
+			return err
+		}
+		return deliveryErr
+	}
+
+	message.NextAttemptAt = now.Add(retryBackoff(message.Attempts))
+	relay.logger.Warn("outbox delivery failed, retrying", zap.String("outbox_message_id", message.ID),
+		zap.String("transaction_id", message.TransactionID), zap.Time("next_attempt_at", message.NextAttemptAt),
+		zap.Error(deliveryErr))
+	return relay.outboxRepository.Save(ctx, message)
+}
+
+// changeState completes the transaction and marks its message processed in the same unit of work
+func (relay *OutboxRelayImp) changeState(ctx context.Context, transaction *domain.Transaction, to domain.TransactionState,
+	event domain.TransactionEvent, message *domain.OutboxMessage) error {
+	return changeTransactionState(ctx, relay.unitOfWork, relay.transactionRepository, transaction, to, event,
+		func(ctx context.Context) error {
+			return relay.outboxRepository.Save(ctx, message)
+		})
+}
+
+// RelayPending delivers the messages due for an attempt, one failing message does not hold back the others.
+// It stops once ctx is cancelled, a message left claimed is delivered again after its lease.
+func (relay *OutboxRelayImp) RelayPending(now time.Time, ctx context.Context) error {
+	messages, err := relay.outboxRepository.FindPending(ctx, now, alias.OutboxBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for i := range messages {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		message := &messages[i]
+		transaction, err := relay.transactionRepository.FindByID(ctx, message.TransactionID)
+		if err != nil {
+			relay.logger.Error("outbox message has no transaction", zap.String("outbox_message_id", message.ID),
+				zap.Error(err))
+			continue
+		}
+		if err := relay.Deliver(transaction, message, ctx); err != nil {
+			relay.logger.Error("outbox delivery failed", zap.String("outbox_message_id", message.ID),
+				zap.String("transaction_id", message.TransactionID), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// newOutboxMessage captures the core posting of the transaction, it is due immediately
+func newOutboxMessage(transaction *domain.Transaction, creation domain.TransactionCreation) (*domain.OutboxMessage, error) {
+	payload, err := json.Marshal(creation)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &domain.OutboxMessage{
+		ID:            uuid.New().String(),
+		TransactionID: transaction.ID,
+		Payload:       string(payload),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+func retryBackoff(attempts int) time.Duration {
+	return alias.OutboxRetryBackoff * time.Duration(1<<uint(attempts-1))
+}
+
+// OutboxRelayScheduler polls for outbox messages left undelivered, after a crash or a failed attempt.
+type OutboxRelayScheduler struct {
+	*scheduler.Scheduler
+}
+
+func NewOutboxRelayScheduler(relay OutboxRelay, interval time.Duration) *OutboxRelayScheduler {
+	return &OutboxRelayScheduler{scheduler.New("outbox relay", interval, relay.RelayPending)}
+}
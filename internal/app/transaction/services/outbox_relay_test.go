@@ -0,0 +1,154 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+	"go.uber.org/zap"
+)
+
+type stubTransactionService struct {
+	err    error
+	calls  int
+	posted []domain.TransactionCreation
+}
+
+func (stub *stubTransactionService) CreateTransaction(transactionCreation domain.TransactionCreation, ctx context.Context) error {
+	stub.calls++
+	stub.posted = append(stub.posted, transactionCreation)
+	return stub.err
+}
+
+type outboxFixture struct {
+	transactions domain.TransactionRepository
+	outbox       domain.OutboxRepository
+	core         *stubTransactionService
+	relay        services.OutboxRelay
+}
+
+func newOutboxFixture(t *testing.T, attempts int, coreErr error) outboxFixture {
+	datastore := inmemory.NewDatastore()
+	fixture := outboxFixture{
+		transactions: inmemory.NewInMemoryTransactionRepository(datastore),
+		outbox:       inmemory.NewInMemoryOutboxRepository(datastore),
+		core:         &stubTransactionService{err: coreErr},
+	}
+	fixture.relay = services.NewOutboxRelay(fixture.core, inmemory.NewInMemoryUnitOfWork(), fixture.transactions, fixture.outbox,
+		zap.NewNop())
+
+	now := time.Now().UTC()
+	if err := fixture.transactions.Save(context.Background(), &domain.Transaction{ID: "trx-1", State: domain.Processing, CreatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(domain.TransactionCreation{Reference: "trx-1", Amount: big.NewFloat(3000)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fixture.outbox.Save(context.Background(), &domain.OutboxMessage{
+		ID:            "message-1",
+		TransactionID: "trx-1",
+		Payload:       string(payload),
+		Attempts:      attempts,
+		NextAttemptAt: now.Add(-time.Second),
+		CreatedAt:     now,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fixture
+}
+
+func (fixture outboxFixture) state(t *testing.T) domain.TransactionState {
+	transaction, err := fixture.transactions.FindByID(context.Background(), "trx-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transaction.State
+}
+
+func TestOutboxRelay_Should_CompleteTheTransaction_When_TheCoreBooksIt(t *testing.T) {
+	fixture := newOutboxFixture(t, 0, nil)
+
+	if err := fixture.relay.RelayPending(time.Now().UTC(), context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if state := fixture.state(t); state != domain.Success {
+		t.Fatalf("state should be success but was %d", state)
+	}
+	pending, _ := fixture.outbox.FindPending(context.Background(), time.Now().UTC().Add(time.Hour), alias.OutboxBatchSize)
+	if len(pending) != 0 {
+		t.Fatal("message should be processed")
+	}
+}
+
+func TestOutboxRelay_Should_RetryLater_When_TheCoreFails(t *testing.T) {
+	fixture := newOutboxFixture(t, 0, errors.New("core unavailable"))
+
+	if err := fixture.relay.RelayPending(time.Now().UTC(), context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if state := fixture.state(t); state != domain.Processing {
+		t.Fatalf("state should stay processing but was %d", state)
+	}
+	if pending, _ := fixture.outbox.FindPending(context.Background(), time.Now().UTC(), alias.OutboxBatchSize); len(pending) != 0 {
+		t.Fatal("message should not be due before its backoff elapsed")
+	}
+	pending, _ := fixture.outbox.FindPending(context.Background(), time.Now().UTC().Add(time.Hour), alias.OutboxBatchSize)
+	if len(pending) != 1 || pending[0].Attempts != 1 || pending[0].LastError != "core unavailable" {
+		t.Fatalf("message should be retried later but was %+v", pending)
+	}
+}
+
+func TestOutboxRelay_Should_FailTheTransaction_When_TheAttemptsAreExhausted(t *testing.T) {
+	fixture := newOutboxFixture(t, alias.OutboxMaxAttempts-1, errors.New("core unavailable"))
+
+	if err := fixture.relay.RelayPending(time.Now().UTC(), context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if state := fixture.state(t); state != domain.Failed {
+		t.Fatalf("state should be failed but was %d", state)
+	}
+	events, _ := fixture.transactions.FindEvents(context.Background(), "trx-1")
+	if len(events) != 1 || events[0].FailureReason != "core unavailable" {
+		t.Fatalf("a failed event should be recorded but got %+v", events)
+	}
+}
+
+func TestOutboxRelay_Should_FailTheTransactionOnTheFirstAttempt_When_TheCoreRejectsIt(t *testing.T) {
+	fixture := newOutboxFixture(t, 0, &domain.TransactionRejectedError{Reason: "insufficient funds"})
+
+	if err := fixture.relay.RelayPending(time.Now().UTC(), context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if state := fixture.state(t); state != domain.Failed {
+		t.Fatalf("state should be failed but was %d", state)
+	}
+	pending, _ := fixture.outbox.FindPending(context.Background(), time.Now().UTC().Add(time.Hour), alias.OutboxBatchSize)
+	if len(pending) != 0 {
+		t.Fatal("a rejected message should not be retried")
+	}
+}
+
+func TestOutboxRelay_Should_NotDeliverTwice_When_TheMessageIsAlreadyLeased(t *testing.T) {
+	fixture := newOutboxFixture(t, 0, errors.New("core unavailable"))
+	transaction, _ := fixture.transactions.FindByID(context.Background(), "trx-1")
+	message := &domain.OutboxMessage{ID: "message-1"}
+
+	if err := fixture.relay.Deliver(transaction, message, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.relay.Deliver(transaction, message, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if fixture.core.calls != 1 {
+		t.Fatalf("core should be called once but was called %d times", fixture.core.calls)
+	}
+}
@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"github.com/tunaiku/mobilebanking/internal/pkg/scheduler"
+	"go.uber.org/zap"
+)
+
+type ScheduledTransactionService interface {
+	ExecuteDueTransactions(now time.Time, ctx context.Context) error
+}
+
+type ScheduledTransactionServiceImp struct {
+	router     TransactionRouter
+	repository domain.TransactionRepository
+	logger     *zap.Logger
+}
+
+func NewScheduledTransactionService(router TransactionRouter, repository domain.TransactionRepository,
+	logger *zap.Logger) ScheduledTransactionService {
+	return &ScheduledTransactionServiceImp{router: router, repository: repository, logger: logger}
+}
+
+func (service *ScheduledTransactionServiceImp) ExecuteDueTransactions(now time.Time, ctx context.Context) error {
+	transactions, err := service.repository.FindDueScheduled(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for i := range transactions {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		transaction := &transactions[i]
+		event := domain.TransactionEvent{ActorUserID: alias.SystemActor}
+		// another instance posting the same transaction first makes the state change fail, it is not an error
+		err := service.router.Post(transaction, event, ctx)
+		if err != nil && err != domain.ErrTransactionStateChanged {
+			service.logger.Error("scheduled transaction failed", zap.String("transaction_id", transaction.ID),
+				zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// TransactionScheduler polls for scheduled transactions which are due and posts them.
+type TransactionScheduler struct {
+	*scheduler.Scheduler
+}
+
+func NewTransactionScheduler(service ScheduledTransactionService, interval time.Duration) *TransactionScheduler {
+	return &TransactionScheduler{scheduler.New("scheduled transaction", interval, service.ExecuteDueTransactions)}
+}
@@ -0,0 +1,49 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+	"go.uber.org/zap"
+)
+
+func TestScheduledTransactionService_Should_RecordTheFailureAndStop_When_ThePostFails(t *testing.T) {
+	datastore := inmemory.NewDatastore()
+	unitOfWork := inmemory.NewInMemoryUnitOfWork()
+	transactions := inmemory.NewInMemoryTransactionRepository(datastore)
+	outbox := inmemory.NewInMemoryOutboxRepository(datastore)
+	core := &stubTransactionService{err: &domain.TransactionRejectedError{Reason: "insufficient funds"}}
+	relay := services.NewOutboxRelay(core, unitOfWork, transactions, outbox, zap.NewNop())
+	router := services.NewTransactionRouter(relay, &stubClearingGateway{}, unitOfWork, transactions, outbox)
+	service := services.NewScheduledTransactionService(router, transactions, zap.NewNop())
+
+	now := time.Now().UTC()
+	executionDate := now.Add(-time.Minute)
+	err := transactions.Save(context.Background(), &domain.Transaction{ID: "trx-1", State: domain.Scheduled,
+		DestinationAccount: "10002", Amount: 3000, ExecutionDate: &executionDate, CreatedAt: now.Add(-time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := service.ExecuteDueTransactions(now, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	transaction, err := transactions.FindByID(context.Background(), "trx-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.State != domain.Failed || transaction.FailureReason != "rejected by the core: insufficient funds" {
+		t.Fatalf("the transaction should fail with the reason of the core, got %d %q", transaction.State, transaction.FailureReason)
+	}
+
+	if err := service.ExecuteDueTransactions(now.Add(time.Hour), context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if core.calls != 1 {
+		t.Fatalf("the transfer should be posted once but was posted %d times", core.calls)
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"go.uber.org/zap"
+)
+
+// SettlementServiceImp completes the interbank transactions left Processing once the clearing reports
+// their outcome. A settled transfer is booked against the clearing settlement account of the core through
+// the outbox, the transaction stays Processing until the outbox relay delivered the booking.
+// Every callback is recorded so a replayed one is rejected, while a new callback repeating the outcome
+// already applied is accepted without changing anything.
+type SettlementServiceImp struct {
+	outboxRelay           OutboxRelay
+	unitOfWork            domain.UnitOfWork
+	transactionRepository domain.TransactionRepository
+	outboxRepository      domain.OutboxRepository
+	callbackRepository    domain.SettlementCallbackRepository
+	logger                *zap.Logger
+}
+
+func NewSettlementService(outboxRelay OutboxRelay, unitOfWork domain.UnitOfWork,
+	transactionRepository domain.TransactionRepository, outboxRepository domain.OutboxRepository,
+	callbackRepository domain.SettlementCallbackRepository, logger *zap.Logger) domain.ClearingSettlementListener {
+	return &SettlementServiceImp{outboxRelay: outboxRelay, unitOfWork: unitOfWork,
+		transactionRepository: transactionRepository, outboxRepository: outboxRepository,
+		callbackRepository: callbackRepository, logger: logger}
+}
+
+func (service *SettlementServiceImp) OnSettlement(ctx context.Context, settlement domain.ClearingSettlement) error {
+	transaction, err := findTransactionForSettlement(ctx, service.transactionRepository, settlement)
+	if err != nil {
+		return domain.ErrSettlementUnknownTransaction
+	}
+
+	callback := &domain.SettlementCallback{
+		ID:            settlement.CallbackID,
+		TransactionID: transaction.ID,
+		Payload:       settlement.Payload,
+		ReceivedAt:    time.Now().UTC(),
+	}
+
+	applied, err := service.isApplied(ctx, transaction)
+	if err != nil {
+		return err
+	}
+	if applied {
+		if !isSettledAs(transaction, settlement) {
+			return domain.ErrSettlementConflict
+		}
+		return service.callbackRepository.Insert(ctx, callback)
+	}
+
+	event := domain.TransactionEvent{ActorUserID: alias.ClearingActor, Payload: settlement.Payload}
+	if settlement.ExternalReference != "" {
+		transaction.ExternalReference = settlement.ExternalReference
+	}
+	if !settlement.Success {
+		event.FailureReason = settlement.FailureReason
+		return service.changeState(ctx, transaction, domain.Failed, event, callback)
+	}
+
+	creation := toTransactionCreation(transaction)
+	creation.DestinationAccount = alias.ClearingSettlementAccount
+	message, err := newOutboxMessage(transaction, creation)
+	if err != nil {
+		return err
+	}
+	err = service.changeState(ctx, transaction, domain.Processing, event, callback,
+		func(ctx context.Context) error {
+			return service.outboxRepository.Save(ctx, message)
+		})
+	if err != nil {
+		return err
+	}
+
+	// the settlement is recorded, a failed booking is retried by the outbox relay unless the core rejected it
+	if err := service.outboxRelay.Deliver(transaction, message, ctx); err != nil {
+		service.logger.Warn("settlement booking failed", zap.String("transaction_id", transaction.ID),
+			zap.Error(err))
+		if transaction.State == domain.Failed {
+			return err
+		}
+	}
+	return nil
+}
+
+// changeState applies the settlement and claims its callback in the same unit of work, a callback
+// delivered concurrently under the same id fails the unique insert as a replay and rolls the change back
+func (service *SettlementServiceImp) changeState(ctx context.Context, transaction *domain.Transaction,
+	to domain.TransactionState, event domain.TransactionEvent, callback *domain.SettlementCallback,
+	writes ...func(ctx context.Context) error) error {
+	claim := func(ctx context.Context) error {
+		return service.callbackRepository.Insert(ctx, callback)
+	}
+	return changeTransactionState(ctx, service.unitOfWork, service.transactionRepository, transaction, to, event,
+		append([]func(ctx context.Context) error{claim}, writes...)...)
+}
+
+// isApplied tells whether an outcome was already applied to the transaction, one still Processing with a
+// callback accepted was settled and waits for the outbox relay to book it
+func (service *SettlementServiceImp) isApplied(ctx context.Context, transaction *domain.Transaction) (bool, error) {
+	if transaction.State != domain.Processing {
+		return true, nil
+	}
+	return service.callbackRepository.ExistsForTransaction(ctx, transaction.ID)
+}
+
+func isSettledAs(transaction *domain.Transaction, settlement domain.ClearingSettlement) bool {
+	if settlement.Success {
+		return transaction.State == domain.Success || transaction.State == domain.Processing
+	}
+	return transaction.State == domain.Failed
+}
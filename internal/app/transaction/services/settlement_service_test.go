@@ -0,0 +1,163 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+	"go.uber.org/zap"
+)
+
+type settlementFixture struct {
+	transactions domain.TransactionRepository
+	outbox       domain.OutboxRepository
+	callbacks    domain.SettlementCallbackRepository
+	core         *stubTransactionService
+	listener     domain.ClearingSettlementListener
+}
+
+func newSettlementFixture(t *testing.T, coreErr error) settlementFixture {
+	datastore := inmemory.NewDatastore()
+	unitOfWork := inmemory.NewInMemoryUnitOfWork()
+	fixture := settlementFixture{
+		transactions: inmemory.NewInMemoryTransactionRepository(datastore),
+		outbox:       inmemory.NewInMemoryOutboxRepository(datastore),
+		callbacks:    inmemory.NewInMemorySettlementCallbackRepository(datastore),
+		core:         &stubTransactionService{err: coreErr},
+	}
+	relay := services.NewOutboxRelay(fixture.core, unitOfWork, fixture.transactions, fixture.outbox, zap.NewNop())
+	fixture.listener = services.NewSettlementService(relay, unitOfWork, fixture.transactions, fixture.outbox,
+		fixture.callbacks, zap.NewNop())
+
+	err := fixture.transactions.Save(context.Background(), &domain.Transaction{ID: "trx-1", State: domain.Processing, CreatedAt: time.Now().UTC()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fixture
+}
+
+func (fixture settlementFixture) state(t *testing.T) domain.TransactionState {
+	transaction, err := fixture.transactions.FindByID(context.Background(), "trx-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return transaction.State
+}
+
+func TestSettlementService_Should_CompleteTheTransaction_When_TheClearingSettlesIt(t *testing.T) {
+	fixture := newSettlementFixture(t, nil)
+
+	err := fixture.listener.OnSettlement(context.Background(), domain.ClearingSettlement{CallbackID: "callback-1", Reference: "trx-1", Success: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := fixture.state(t); state != domain.Success {
+		t.Fatalf("state should be success but was %d", state)
+	}
+	if len(fixture.core.posted) != 1 || fixture.core.posted[0].DestinationAccount != alias.ClearingSettlementAccount {
+		t.Fatalf("the transfer should be booked once against the clearing settlement account, got %+v", fixture.core.posted)
+	}
+}
+
+func TestSettlementService_Should_KeepTheBookingInTheOutbox_When_TheCoreIsDown(t *testing.T) {
+	fixture := newSettlementFixture(t, errors.New("core unavailable"))
+
+	err := fixture.listener.OnSettlement(context.Background(), domain.ClearingSettlement{CallbackID: "callback-1", Reference: "trx-1", Success: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if state := fixture.state(t); state != domain.Processing {
+		t.Fatalf("state should stay processing but was %d", state)
+	}
+	pending, err := fixture.outbox.FindPending(context.Background(), time.Now().UTC().Add(time.Hour), alias.OutboxBatchSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("the booking should wait in the outbox but %d messages are pending", len(pending))
+	}
+}
+
+func TestSettlementService_Should_FailTheTransaction_When_TheCoreRejectsTheBooking(t *testing.T) {
+	fixture := newSettlementFixture(t, &domain.TransactionRejectedError{Reason: "closed account"})
+
+	err := fixture.listener.OnSettlement(context.Background(), domain.ClearingSettlement{CallbackID: "callback-1", Reference: "trx-1", Success: true})
+	var rejected *domain.TransactionRejectedError
+	if !errors.As(err, &rejected) {
+		t.Fatalf("err should be a `*domain.TransactionRejectedError` but was %v", err)
+	}
+	if state := fixture.state(t); state != domain.Failed {
+		t.Fatalf("state should be failed but was %d", state)
+	}
+}
+
+func TestSettlementService_Should_NotBookTheTransferAgain_When_AnotherCallbackRepeatsTheSettlement(t *testing.T) {
+	fixture := newSettlementFixture(t, errors.New("core unavailable"))
+
+	if err := fixture.listener.OnSettlement(context.Background(), domain.ClearingSettlement{CallbackID: "callback-1", Reference: "trx-1", Success: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.listener.OnSettlement(context.Background(), domain.ClearingSettlement{CallbackID: "callback-2", Reference: "trx-1", Success: true}); err != nil {
+		t.Fatal(err)
+	}
+	pending, err := fixture.outbox.FindPending(context.Background(), time.Now().UTC().Add(time.Hour), alias.OutboxBatchSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 1 {
+		t.Fatalf("a single booking should be enqueued but %d messages are pending", len(pending))
+	}
+
+	err = fixture.listener.OnSettlement(context.Background(), domain.ClearingSettlement{CallbackID: "callback-3", Reference: "trx-1", FailureReason: "rejected"})
+	if err != domain.ErrSettlementConflict {
+		t.Fatalf("err should be `domain.ErrSettlementConflict` but was %v", err)
+	}
+}
+
+func TestSettlementService_Should_RejectTheCallback_When_ItIsReplayed(t *testing.T) {
+	fixture := newSettlementFixture(t, nil)
+	settlement := domain.ClearingSettlement{CallbackID: "callback-1", Reference: "trx-1", Success: true}
+
+	if err := fixture.listener.OnSettlement(context.Background(), settlement); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.listener.OnSettlement(context.Background(), settlement); err != domain.ErrSettlementReplayed {
+		t.Fatalf("err should be `domain.ErrSettlementReplayed` but was %v", err)
+	}
+}
+
+func TestSettlementService_Should_ReturnAConflict_When_TheOutcomeDiffers(t *testing.T) {
+	fixture := newSettlementFixture(t, nil)
+
+	if err := fixture.listener.OnSettlement(context.Background(), domain.ClearingSettlement{CallbackID: "callback-1", Reference: "trx-1", Success: true}); err != nil {
+		t.Fatal(err)
+	}
+	err := fixture.listener.OnSettlement(context.Background(), domain.ClearingSettlement{CallbackID: "callback-2", Reference: "trx-1", FailureReason: "rejected"})
+	if err != domain.ErrSettlementConflict {
+		t.Fatalf("err should be `domain.ErrSettlementConflict` but was %v", err)
+	}
+}
+
+func TestSettlementService_Should_NotPostTheTransfer_When_TheCallbackIsClaimedConcurrently(t *testing.T) {
+	fixture := newSettlementFixture(t, nil)
+	// a concurrent delivery of the callback claimed it first
+	if err := fixture.callbacks.Insert(context.Background(), &domain.SettlementCallback{ID: "callback-1", TransactionID: "trx-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	err := fixture.listener.OnSettlement(context.Background(), domain.ClearingSettlement{CallbackID: "callback-1", Reference: "trx-1", Success: true})
+	if err != domain.ErrSettlementReplayed {
+		t.Fatalf("err should be `domain.ErrSettlementReplayed` but was %v", err)
+	}
+	if fixture.core.calls != 0 {
+		t.Fatalf("the transfer should not be posted but was posted %d times", fixture.core.calls)
+	}
+	if state := fixture.state(t); state != domain.Processing {
+		t.Fatalf("state should stay processing but was %d", state)
+	}
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
+	"github.com/tunaiku/mobilebanking/internal/pkg/tracing"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+const tracerName = "github.com/tunaiku/mobilebanking/transaction"
+
+var (
+	transactionIDKey   = kv.Key("transaction.id")
+	transactionCodeKey = kv.Key("transaction.code")
+	authMethodKey      = kv.Key("transaction.auth_method")
+)
+
+// tracedTransactionCompositionService wraps every operation of the composition service in a span, the
+// repository queries and core banking calls made underneath become its children
+type tracedTransactionCompositionService struct {
+	service TransactionCompositionService
+	tracer  trace.Tracer
+}
+
+func NewTracedTransactionCompositionService(service TransactionCompositionService) TransactionCompositionService {
+	return &tracedTransactionCompositionService{service: service, tracer: tracing.Tracer(tracerName)}
+}
+
+func (traced *tracedTransactionCompositionService) start(ctx context.Context, operation string,
+	attributes ...kv.KeyValue) (context.Context, trace.Span) {
+	return traced.tracer.Start(ctx, "TransactionCompositionService."+operation, trace.WithAttributes(attributes...))
+}
+
+func (traced *tracedTransactionCompositionService) CreateTransaction(dto *dto.CreateTransactionDto,
+	ctx context.Context) (transaction *domain.Transaction, err error) {
+	ctx, span := traced.start(ctx, "CreateTransaction", transactionCodeKey.String(dto.TransactionCode),
+		authMethodKey.String(dto.AuthMethod))
+	defer func() { tracing.End(ctx, span, err) }()
+
+	transaction, err = traced.service.CreateTransaction(dto, ctx)
+	if transaction != nil {
+		span.SetAttributes(transactionIDKey.String(transaction.ID))
+	}
+	return transaction, err
+}
+
+func (traced *tracedTransactionCompositionService) QuoteTransaction(dto *dto.QuoteTransactionDto,
+	ctx context.Context) (quote domain.TransactionQuote, err error) {
+	ctx, span := traced.start(ctx, "QuoteTransaction", transactionCodeKey.String(dto.TransactionCode))
+	defer func() { tracing.End(ctx, span, err) }()
+
+	return traced.service.QuoteTransaction(dto, ctx)
+}
+
+func (traced *tracedTransactionCompositionService) VerifyTransaction(dto *dto.VerifyTransactionDto,
+	ctx context.Context) (transaction *domain.Transaction, err error) {
+	ctx, span := traced.start(ctx, "VerifyTransaction", transactionIDKey.String(dto.ID))
+	defer func() { tracing.End(ctx, span, err) }()
+
+	return traced.service.VerifyTransaction(dto, ctx)
+}
+
+func (traced *tracedTransactionCompositionService) GetTransaction(id string,
+	ctx context.Context) (transaction domain.Transaction, err error) {
+	ctx, span := traced.start(ctx, "GetTransaction", transactionIDKey.String(id))
+	defer func() { tracing.End(ctx, span, err) }()
+
+	return traced.service.GetTransaction(id, ctx)
+}
+
+func (traced *tracedTransactionCompositionService) GetTransactionEvents(id string,
+	ctx context.Context) (events []domain.TransactionEvent, err error) {
+	ctx, span := traced.start(ctx, "GetTransactionEvents", transactionIDKey.String(id))
+	defer func() { tracing.End(ctx, span, err) }()
+
+	return traced.service.GetTransactionEvents(id, ctx)
+}
+
+func (traced *tracedTransactionCompositionService) ExecuteAuthorizedTransaction(dto *dto.CreateTransactionDto,
+	userSession domain.UserSession, ctx context.Context) (transaction *domain.Transaction, err error) {
+	ctx, span := traced.start(ctx, "ExecuteAuthorizedTransaction", transactionCodeKey.String(dto.TransactionCode))
+	defer func() { tracing.End(ctx, span, err) }()
+
+	transaction, err = traced.service.ExecuteAuthorizedTransaction(dto, userSession, ctx)
+	if transaction != nil {
+		span.SetAttributes(transactionIDKey.String(transaction.ID))
+	}
+	return transaction, err
+}
@@ -3,40 +3,125 @@ package services
 import (
 	"context"
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
-	"github.com/tunaiku/mobilebanking/internal/pkg/pg"
 )
 
 type TransactionCompositionService interface {
-	CreateTransaction(dto *dto.CreateTransactionDto, ctx context.Context) (string, error)
-	VerifyTransaction(dto *dto.VerifyTransactionDto, ctx context.Context) error
-	GetTransaction(id string) (domain.Transaction, error)
+	CreateTransaction(dto *dto.CreateTransactionDto, ctx context.Context) (*domain.Transaction, error)
+	QuoteTransaction(dto *dto.QuoteTransactionDto, ctx context.Context) (domain.TransactionQuote, error)
+	VerifyTransaction(dto *dto.VerifyTransactionDto, ctx context.Context) (*domain.Transaction, error)
+	GetTransaction(id string, ctx context.Context) (domain.Transaction, error)
+	GetTransactionEvents(id string, ctx context.Context) ([]domain.TransactionEvent, error)
+	ExecuteAuthorizedTransaction(dto *dto.CreateTransactionDto, userSession domain.UserSession, ctx context.Context) (*domain.Transaction, error)
 }
 
 type TransactionCompositionServiceImp struct {
 	createTransactionService CreateTransactionService
 	verifyTransactionService VerifyTransactionService
+	router                   TransactionRouter
+	feeService               TransactionFeeService
+	userSession              domain.UserSessionHelper
+	repository               domain.TransactionRepository
 }
 
 func NewTransactionCompositionService(
 	createTransactionService CreateTransactionService,
-	verifyTransactionService VerifyTransactionService) TransactionCompositionService {
+	verifyTransactionService VerifyTransactionService,
+	router TransactionRouter,
+	feeService TransactionFeeService,
+	userSession domain.UserSessionHelper,
+	repository domain.TransactionRepository) TransactionCompositionService {
 	return &TransactionCompositionServiceImp{
 		createTransactionService: createTransactionService,
 		verifyTransactionService: verifyTransactionService,
+		router:                   router,
+		feeService:               feeService,
+		userSession:              userSession,
+		repository:               repository,
 	}
 }
 
-func (inst *TransactionCompositionServiceImp) CreateTransaction(dto *dto.CreateTransactionDto, ctx context.Context) (string, error) {
+func (inst *TransactionCompositionServiceImp) CreateTransaction(dto *dto.CreateTransactionDto, ctx context.Context) (*domain.Transaction, error) {
 	return inst.createTransactionService.Invoke(dto, ctx)
 }
 
-func (inst *TransactionCompositionServiceImp) VerifyTransaction(dto *dto.VerifyTransactionDto, ctx context.Context) error {
+// QuoteTransaction previews the fee and the total debited for a transaction without creating it
+func (inst *TransactionCompositionServiceImp) QuoteTransaction(dto *dto.QuoteTransactionDto, ctx context.Context) (domain.TransactionQuote, error) {
+	userSession, err := inst.userSession.GetFromContext(ctx)
+	if err != nil {
+		return domain.TransactionQuote{}, err
+	}
+
+	if err := ValidateTransactionCode(dto.TransactionCode); err != nil {
+		return domain.TransactionQuote{}, err
+	}
+
+	if err := ValidateAmount(dto.Amount); err != nil {
+		return domain.TransactionQuote{}, err
+	}
+
+	return inst.feeService.Quote(userSession.ID, dto.TransactionCode, dto.Amount, ctx)
+}
+
+func (inst *TransactionCompositionServiceImp) VerifyTransaction(dto *dto.VerifyTransactionDto, ctx context.Context) (*domain.Transaction, error) {
 	return inst.verifyTransactionService.Invoke(dto, ctx)
 }
 
-func (inst *TransactionCompositionServiceImp) GetTransaction(id string) (domain.Transaction, error) {
-	transaction := domain.Transaction{ID: id}
-	err := pg.Wrap(nil).Load(&transaction)
-	return transaction, err
+func (inst *TransactionCompositionServiceImp) GetTransaction(id string, ctx context.Context) (domain.Transaction, error) {
+	transaction, err := inst.findOwnTransaction(id, ctx)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	return *transaction, nil
+}
+
+// GetTransactionEvents returns the audit trail of the transaction to its owner and to the support staff
+// investigating a dispute
+func (inst *TransactionCompositionServiceImp) GetTransactionEvents(id string, ctx context.Context) ([]domain.TransactionEvent, error) {
+	if _, err := inst.findTransaction(id, ctx, domain.SupportRole); err != nil {
+		return nil, err
+	}
+	return inst.repository.FindEvents(ctx, id)
+}
+
+// findOwnTransaction reports the transactions of other users as missing, their details are only shown to the owner
+func (inst *TransactionCompositionServiceImp) findOwnTransaction(id string, ctx context.Context) (*domain.Transaction, error) {
+	return inst.findTransaction(id, ctx)
+}
+
+// findTransaction reports the transactions of other users as missing unless the user has one of the given roles
+func (inst *TransactionCompositionServiceImp) findTransaction(id string, ctx context.Context,
+	roles ...domain.UserRole) (*domain.Transaction, error) {
+	userSession, err := inst.userSession.GetFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	transaction, err := inst.repository.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if transaction.UserID == userSession.ID {
+		return transaction, nil
+	}
+	for _, role := range roles {
+		if userSession.Role == role {
+			return transaction, nil
+		}
+	}
+	return nil, alias.ErrMessageTransactionNotFound
+}
+
+// ExecuteAuthorizedTransaction creates a transaction through the regular create path and posts it
+// straight away, used by callers holding an authorization given up front such as standing orders.
+func (inst *TransactionCompositionServiceImp) ExecuteAuthorizedTransaction(dto *dto.CreateTransactionDto, userSession domain.UserSession,
+	ctx context.Context) (*domain.Transaction, error) {
+	dto.PreAuthorized = true
+	transaction, err := inst.createTransactionService.InvokeWithSession(dto, userSession, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	event := domain.TransactionEvent{ActorUserID: alias.SystemActor}
+	return transaction, inst.router.Post(transaction, event, ctx)
 }
@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+)
+
+func newCompositionFixture(t *testing.T) (services.TransactionCompositionService, *stubUserSessionHelper) {
+	fixture := newVerifyFixture(t)
+	transaction, err := fixture.transactions.FindByID(context.Background(), "trx-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fixture.transactions.UpdateState(context.Background(), transaction, domain.TransactionEvent{
+		ID:            "event-1",
+		TransactionID: "trx-1",
+		ToState:       domain.WaitAuthorization,
+		ActorUserID:   johnID,
+		ClientIP:      "203.0.113.7",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := services.NewTransactionCompositionService(nil, fixture.service, fixture.router, nil, fixture.session,
+		fixture.transactions)
+	return service, fixture.session
+}
+
+func TestTransactionCompositionService_Should_ReturnTheAuditTrail_When_TheOwnerAsks(t *testing.T) {
+	service, _ := newCompositionFixture(t)
+
+	events, err := service.GetTransactionEvents("trx-1", context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected a single event, got %d", len(events))
+	}
+}
+
+func TestTransactionCompositionService_Should_ReturnNotFound_When_AnotherUserAsks(t *testing.T) {
+	service, session := newCompositionFixture(t)
+	session.user = pinUser(janeID)
+
+	if _, err := service.GetTransaction("trx-1", context.Background()); err != alias.ErrMessageTransactionNotFound {
+		t.Fatalf("err should be `alias.ErrMessageTransactionNotFound` but was %v", err)
+	}
+	if _, err := service.GetTransactionEvents("trx-1", context.Background()); err != alias.ErrMessageTransactionNotFound {
+		t.Fatalf("err should be `alias.ErrMessageTransactionNotFound` but was %v", err)
+	}
+}
+
+func TestTransactionCompositionService_Should_ReturnTheAuditTrail_When_TheSupportStaffAsks(t *testing.T) {
+	service, session := newCompositionFixture(t)
+	session.user = &domain.User{ID: "support-1", Role: domain.SupportRole}
+
+	events, err := service.GetTransactionEvents("trx-1", context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected a single event, got %d", len(events))
+	}
+	if _, err := service.GetTransaction("trx-1", context.Background()); err != alias.ErrMessageTransactionNotFound {
+		t.Fatalf("err should be `alias.ErrMessageTransactionNotFound` but was %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"math/big"
+
+	bankAlias "github.com/tunaiku/mobilebanking/internal/app/bank/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+)
+
+// TransactionRouter posts authorized transactions, intra-bank transfers are booked by the core through
+// the outbox while interbank ones are submitted to the clearing and stay Processing until it settles them.
+// The writes run in the unit of work moving the transaction to Processing, which commits before anything
+// is sent to the core or the clearing.
+type TransactionRouter interface {
+	Post(transaction *domain.Transaction, event domain.TransactionEvent, ctx context.Context,
+		writes ...func(ctx context.Context) error) error
+}
+
+type TransactionRouterImp struct {
+	outboxRelay      OutboxRelay
+	clearingGateway  domain.ClearingGateway
+	unitOfWork       domain.UnitOfWork
+	repository       domain.TransactionRepository
+	outboxRepository domain.OutboxRepository
+}
+
+func NewTransactionRouter(outboxRelay OutboxRelay, clearingGateway domain.ClearingGateway, unitOfWork domain.UnitOfWork,
+	repository domain.TransactionRepository, outboxRepository domain.OutboxRepository) TransactionRouter {
+	return &TransactionRouterImp{outboxRelay: outboxRelay, clearingGateway: clearingGateway, unitOfWork: unitOfWork,
+		repository: repository, outboxRepository: outboxRepository}
+}
+
+func (router *TransactionRouterImp) Post(transaction *domain.Transaction, event domain.TransactionEvent, ctx context.Context,
+	writes ...func(ctx context.Context) error) error {
+	if isInterbank(transaction.DestinationBankCode) {
+		return router.submitToClearing(transaction, event, ctx, writes...)
+	}
+	return router.postThroughOutbox(transaction, event, ctx, writes...)
+}
+
+// postThroughOutbox moves the transaction to Processing together with its outbox message and tries to
+// deliver it right away, a failed delivery is retried by the outbox relay while the transaction stays Processing.
+func (router *TransactionRouterImp) postThroughOutbox(transaction *domain.Transaction, event domain.TransactionEvent,
+	ctx context.Context, writes ...func(ctx context.Context) error) error {
+	message, err := newOutboxMessage(transaction, toTransactionCreation(transaction))
+	if err != nil {
+		return err
+	}
+	writes = append(writes, func(ctx context.Context) error {
+		return router.outboxRepository.Save(ctx, message)
+	})
+	err = changeTransactionState(ctx, router.unitOfWork, router.repository, transaction, domain.Processing, event,
+		writes...)
+	if err != nil {
+		return err
+	}
+
+	if err := router.outboxRelay.Deliver(transaction, message, ctx); err != nil && transaction.State == domain.Failed {
+		return err
+	}
+	return nil
+}
+
+func (router *TransactionRouterImp) submitToClearing(transaction *domain.Transaction, event domain.TransactionEvent,
+	ctx context.Context, writes ...func(ctx context.Context) error) error {
+	err := changeTransactionState(ctx, router.unitOfWork, router.repository, transaction, domain.Processing, event,
+		writes...)
+	if err != nil {
+		return err
+	}
+
+	externalReference, err := router.clearingGateway.Submit(domain.ClearingTransfer{
+		Reference:           transaction.ID,
+		SourceAccount:       transaction.SourceAccount,
+		DestinationBankCode: transaction.DestinationBankCode,
+		DestinationAccount:  transaction.DestinationAccount,
+		Amount:              big.NewFloat(transaction.Amount),
+		Fee:                 big.NewFloat(transaction.Fee),
+		Currency:            alias.Currency,
+	})
+	if err != nil {
+		event.FailureReason = err.Error()
+		if stateErr := router.changeState(ctx, transaction, domain.Failed, event); stateErr != nil {
+			return stateErr
+		}
+		return err
+	}
+
+	transaction.ExternalReference = externalReference
+	return router.repository.UpdateExternalReference(ctx, transaction)
+}
+
+func (router *TransactionRouterImp) changeState(ctx context.Context, transaction *domain.Transaction,
+	to domain.TransactionState, event domain.TransactionEvent) error {
+	return changeTransactionState(ctx, router.unitOfWork, router.repository, transaction, to, event)
+}
+
+func isInterbank(bankCode string) bool {
+	return bankCode != "" && bankCode != bankAlias.InternalBankCode
+}
@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+	"go.uber.org/zap"
+)
+
+type stubClearingGateway struct {
+	submitted []domain.ClearingTransfer
+}
+
+func (stub *stubClearingGateway) InquireAccount(bankCode string, accountNumber string) (domain.AccountInquiry, error) {
+	return domain.AccountInquiry{}, nil
+}
+
+func (stub *stubClearingGateway) Submit(transfer domain.ClearingTransfer) (string, error) {
+	stub.submitted = append(stub.submitted, transfer)
+	return "clearing-1", nil
+}
+
+func TestTransactionRouter_Should_SubmitTheAmountAndTheFee_When_TheTransferIsInterbank(t *testing.T) {
+	datastore := inmemory.NewDatastore()
+	unitOfWork := inmemory.NewInMemoryUnitOfWork()
+	transactions := inmemory.NewInMemoryTransactionRepository(datastore)
+	outbox := inmemory.NewInMemoryOutboxRepository(datastore)
+	clearing := &stubClearingGateway{}
+	relay := services.NewOutboxRelay(&stubTransactionService{}, unitOfWork, transactions, outbox, zap.NewNop())
+	router := services.NewTransactionRouter(relay, clearing, unitOfWork, transactions, outbox)
+
+	transaction := &domain.Transaction{ID: "trx-1", State: domain.WaitAuthorization, DestinationBankCode: "BCA",
+		DestinationAccount: "20001", Amount: 3000, Fee: 6500, CreatedAt: time.Now().UTC()}
+	if err := transactions.Save(context.Background(), transaction); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := router.Post(transaction, domain.TransactionEvent{}, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(clearing.submitted) != 1 {
+		t.Fatalf("the transfer should be submitted once but was submitted %d times", len(clearing.submitted))
+	}
+	amount, _ := clearing.submitted[0].Amount.Float64()
+	fee, _ := clearing.submitted[0].Fee.Float64()
+	if amount != 3000 || fee != 6500 {
+		t.Fatalf("the clearing should receive the amount 3000 and the fee 6500 but got %v and %v", amount, fee)
+	}
+}
+
+func TestTransactionRouter_Should_PostTheTransferOnce_When_TwoSchedulersPickTheSameTransaction(t *testing.T) {
+	datastore := inmemory.NewDatastore()
+	unitOfWork := inmemory.NewInMemoryUnitOfWork()
+	transactions := inmemory.NewInMemoryTransactionRepository(datastore)
+	outbox := inmemory.NewInMemoryOutboxRepository(datastore)
+	core := &stubTransactionService{}
+	relay := services.NewOutboxRelay(core, unitOfWork, transactions, outbox, zap.NewNop())
+	router := services.NewTransactionRouter(relay, &stubClearingGateway{}, unitOfWork, transactions, outbox)
+
+	err := transactions.Save(context.Background(), &domain.Transaction{ID: "trx-1", State: domain.Scheduled,
+		DestinationAccount: "10002", Amount: 3000, CreatedAt: time.Now().UTC()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, _ := transactions.FindByID(context.Background(), "trx-1")
+	second, _ := transactions.FindByID(context.Background(), "trx-1")
+
+	if err := router.Post(first, domain.TransactionEvent{}, context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := router.Post(second, domain.TransactionEvent{}, context.Background()); err != domain.ErrTransactionStateChanged {
+		t.Fatalf("err should be `domain.ErrTransactionStateChanged` but was %v", err)
+	}
+	if len(core.posted) != 1 {
+		t.Fatalf("the transfer should be posted once but was posted %d times", len(core.posted))
+	}
+}
+
+func TestTransactionRouter_Should_NotPostTheTransfer_When_AWriteOfTheUnitOfWorkFails(t *testing.T) {
+	datastore := inmemory.NewDatastore()
+	unitOfWork := inmemory.NewInMemoryUnitOfWork()
+	transactions := inmemory.NewInMemoryTransactionRepository(datastore)
+	outbox := inmemory.NewInMemoryOutboxRepository(datastore)
+	core := &stubTransactionService{}
+	relay := services.NewOutboxRelay(core, unitOfWork, transactions, outbox, zap.NewNop())
+	router := services.NewTransactionRouter(relay, &stubClearingGateway{}, unitOfWork, transactions, outbox)
+
+	transaction := &domain.Transaction{ID: "trx-1", State: domain.WaitAuthorization, DestinationAccount: "10002",
+		Amount: 3000, CreatedAt: time.Now().UTC()}
+	if err := transactions.Save(context.Background(), transaction); err != nil {
+		t.Fatal(err)
+	}
+
+	limitErr := errors.New("daily amount limit exceeded")
+	err := router.Post(transaction, domain.TransactionEvent{}, context.Background(), func(ctx context.Context) error {
+		return limitErr
+	})
+	if err != limitErr {
+		t.Fatalf("err should be the failed write but was %v", err)
+	}
+	if len(core.posted) != 0 {
+		t.Fatalf("nothing should be posted to the core but %d transfers were", len(core.posted))
+	}
+	saved, _ := transactions.FindByID(context.Background(), "trx-1")
+	if saved.State != domain.WaitAuthorization {
+		t.Fatalf("the transaction should keep waiting for its authorization, got state %d", saved.State)
+	}
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+// changeTransactionState moves the transaction to the given state and records the audit event describing
+// it, the writes given are made in the same unit of work so they commit or roll back with the change.
+func changeTransactionState(ctx context.Context, unitOfWork domain.UnitOfWork, repository domain.TransactionRepository,
+	transaction *domain.Transaction, to domain.TransactionState, event domain.TransactionEvent,
+	writes ...func(ctx context.Context) error) error {
+	from := transaction.State
+	event.ID = uuid.New().String()
+	event.TransactionID = transaction.ID
+	event.FromState = from
+	event.ToState = to
+	event.AuthorizationMethod = transaction.AuthorizationMethod
+	event.CreatedAt = time.Now().UTC()
+
+	reason := transaction.FailureReason
+	transaction.State = to
+	if event.FailureReason != "" {
+		transaction.FailureReason = event.FailureReason
+	}
+	err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+		for _, write := range writes {
+			if err := write(ctx); err != nil {
+				return err
+			}
+		}
+		return repository.UpdateState(ctx, transaction, event)
+	})
+	if err != nil {
+		transaction.State = from
+		transaction.FailureReason = reason
+	}
+	return err
+}
+
+// findTransactionForSettlement looks the transaction up by our id, or by the clearing's reference
+func findTransactionForSettlement(ctx context.Context, repository domain.TransactionRepository,
+	settlement domain.ClearingSettlement) (*domain.Transaction, error) {
+	if settlement.Reference != "" {
+		return repository.FindByID(ctx, settlement.Reference)
+	}
+	return repository.FindByExternalReference(ctx, settlement.ExternalReference)
+}
@@ -2,42 +2,151 @@ package services
 
 import (
 	"context"
+	"math/big"
+	"time"
+
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
 )
 
 type VerifyTransactionService interface {
-	Invoke(dto *dto.VerifyTransactionDto, r context.Context) error
+	Invoke(dto *dto.VerifyTransactionDto, r context.Context) (*domain.Transaction, error)
 }
 
 type VerifyTransactionServiceImp struct {
 	userSession          domain.UserSessionHelper
 	otpCredentialManager domain.OtpCredentialManager
 	pinCredentialManager domain.PinCredentialManager
+	limitService         domain.TransactionLimitService
+	router               TransactionRouter
+	unitOfWork           domain.UnitOfWork
+	repository           domain.TransactionRepository
+	metrics              *metrics.Metrics
 }
 
 func NewVerifyTransactionService(userSession domain.UserSessionHelper, otpCredentialManager domain.OtpCredentialManager,
-	pinCredentialManager domain.PinCredentialManager) VerifyTransactionService {
+	pinCredentialManager domain.PinCredentialManager, limitService domain.TransactionLimitService,
+	router TransactionRouter, unitOfWork domain.UnitOfWork, repository domain.TransactionRepository,
+	metrics *metrics.Metrics) VerifyTransactionService {
 	return &VerifyTransactionServiceImp{userSession: userSession, otpCredentialManager: otpCredentialManager,
-		pinCredentialManager: pinCredentialManager}
+		pinCredentialManager: pinCredentialManager, limitService: limitService, router: router,
+		unitOfWork: unitOfWork, repository: repository, metrics: metrics}
 }
 
-func (service *VerifyTransactionServiceImp) Invoke(dto *dto.VerifyTransactionDto, r context.Context) error {
+// Invoke validates the credentials given for the pending authorization methods, the transaction stays
+// in WaitAuthorization until every method required by the authorization policy is verified.
+func (service *VerifyTransactionServiceImp) Invoke(dto *dto.VerifyTransactionDto, r context.Context) (*domain.Transaction, error) {
 	userSession, err := service.userSession.GetFromContext(r)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if userSession.ConfiguredTransactionCredential.Otp != nil {
-		return validateOtp(userSession, dto.Credential)
+	transaction, err := service.repository.FindByID(r, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+	// a transaction of another user is reported missing so the credentials of one user never authorize it
+	if transaction.UserID != userSession.ID {
+		return nil, alias.ErrMessageTransactionNotFound
 	}
 
-	if userSession.ConfiguredTransactionCredential.Pin != nil {
-		return validatePin(userSession, dto.Credential)
+	if transaction.State != domain.WaitAuthorization {
+		return nil, alias.ErrMessageTransactionHadVerified
 	}
 
-	return nil
+	pending := transaction.PendingAuthorizations()
+	credentials := collectCredentials(dto, pending)
+	if len(credentials) == 0 {
+		return nil, alias.ErrMessageCredentialRequired
+	}
+
+	for _, method := range pending {
+		credential, ok := credentials[method]
+		if !ok {
+			continue
+		}
+		if err := validateCredential(method, userSession, credential); err != nil {
+			service.metrics.Verification(metrics.VerificationFailed)
+			return nil, err
+		}
+		transaction.CompletedAuthorizations = append(transaction.CompletedAuthorizations, method)
+	}
+	service.metrics.Verification(metrics.VerificationSucceeded)
+
+	if len(transaction.PendingAuthorizations()) > 0 {
+		return transaction, service.repository.Save(r, transaction)
+	}
+
+	event := domain.TransactionEvent{ActorUserID: userSession.ID, ClientIP: dto.ClientIP}
+	var writes []func(ctx context.Context) error
+	// an authorization older than the window stopped counting against the limits, they are checked again
+	// in the unit of work moving the transaction on
+	if transaction.CreatedAt.Before(time.Now().UTC().Add(-alias.AuthorizationWindow)) {
+		writes = append(writes, func(ctx context.Context) error {
+			return service.limitService.CheckLimit(transaction.UserID, transaction.SourceAccount,
+				transaction.TransactionCode, transaction.Amount, transaction.Fee, ctx)
+		})
+	}
+
+	return transaction, service.authorize(transaction, event, r, writes...)
+}
+
+// authorize moves the fully authorized transaction on, it is scheduled when it executes later and posted otherwise
+func (service *VerifyTransactionServiceImp) authorize(transaction *domain.Transaction, event domain.TransactionEvent,
+	ctx context.Context, writes ...func(ctx context.Context) error) error {
+	if transaction.ExecutionDate != nil && transaction.ExecutionDate.After(time.Now()) {
+		return changeTransactionState(ctx, service.unitOfWork, service.repository, transaction, domain.Scheduled, event,
+			writes...)
+	}
+	return service.router.Post(transaction, event, ctx, writes...)
+}
+
+// collectCredentials maps the credentials of the request to the pending methods, a single credential
+// without method name is used for the first pending method not covered by the named ones
+func collectCredentials(dto *dto.VerifyTransactionDto, pending []domain.AuthorizationMethod) map[domain.AuthorizationMethod]string {
+	credentials := map[domain.AuthorizationMethod]string{}
+	for name, credential := range dto.Credentials {
+		if method, ok := alias.AuthMethods[name]; ok {
+			credentials[method] = credential
+		}
+	}
+
+	if dto.Credential == "" {
+		return credentials
+	}
+	for _, method := range pending {
+		if _, ok := credentials[method]; !ok {
+			credentials[method] = dto.Credential
+			break
+		}
+	}
+	return credentials
+}
+
+func validateCredential(authMethod domain.AuthorizationMethod, userSession domain.UserSession, credential string) error {
+	switch authMethod {
+	case domain.OtpAuthorization:
+		return validateOtp(userSession, credential)
+	case domain.PinAuthorization:
+		return validatePin(userSession, credential)
+	default:
+		return alias.ErrMessageMethodNotSupported
+	}
+}
+
+func toTransactionCreation(transaction *domain.Transaction) domain.TransactionCreation {
+	return domain.TransactionCreation{
+		SourceAccount:      transaction.SourceAccount,
+		DestinationAccount: transaction.DestinationAccount,
+		TransactionCode:    transaction.TransactionCode,
+		Amount:             big.NewFloat(transaction.Amount),
+		Fee:                big.NewFloat(transaction.Fee),
+		Currency:           alias.Currency,
+		TransactionDate:    transaction.ExecutionDate,
+		Reference:          transaction.ID,
+	}
 }
 
 func validateOtp(userSession domain.UserSession, credential string) error {
@@ -0,0 +1,118 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/alias"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/dto"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/repository/inmemory"
+	"github.com/tunaiku/mobilebanking/internal/app/transaction/services"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+)
+
+const (
+	johnID = "john"
+	janeID = "jane"
+)
+
+type stubUserSessionHelper struct {
+	user *domain.User
+}
+
+func (stub *stubUserSessionHelper) GetFromContext(ctx context.Context) (domain.UserSession, error) {
+	return domain.UserSession{User: stub.user}, nil
+}
+
+func pinUser(id string) *domain.User {
+	return &domain.User{ID: id, ConfiguredTransactionCredential: &domain.ConfiguredCredential{
+		Pin: &domain.PinCredential{Pin: alias.DefaultPin},
+	}}
+}
+
+type stubTransactionRouter struct {
+	posted []string
+}
+
+func (stub *stubTransactionRouter) Post(transaction *domain.Transaction, event domain.TransactionEvent, ctx context.Context,
+	writes ...func(ctx context.Context) error) error {
+	for _, write := range writes {
+		if err := write(ctx); err != nil {
+			return err
+		}
+	}
+	stub.posted = append(stub.posted, transaction.ID)
+	return nil
+}
+
+type verifyFixture struct {
+	session      *stubUserSessionHelper
+	transactions domain.TransactionRepository
+	router       *stubTransactionRouter
+	service      services.VerifyTransactionService
+}
+
+func newVerifyFixture(t *testing.T) verifyFixture {
+	fixture := verifyFixture{
+		session:      &stubUserSessionHelper{user: pinUser(johnID)},
+		transactions: inmemory.NewInMemoryTransactionRepository(inmemory.NewDatastore()),
+		router:       &stubTransactionRouter{},
+	}
+	fixture.service = services.NewVerifyTransactionService(fixture.session, nil, nil, nil, fixture.router,
+		inmemory.NewInMemoryUnitOfWork(), fixture.transactions, metrics.New())
+
+	err := fixture.transactions.Save(context.Background(), &domain.Transaction{
+		ID:                     "trx-1",
+		UserID:                 johnID,
+		State:                  domain.WaitAuthorization,
+		RequiredAuthorizations: []domain.AuthorizationMethod{domain.PinAuthorization},
+		CreatedAt:              time.Now().UTC(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fixture
+}
+
+func TestVerifyTransactionService_Should_PostTheTransaction_When_TheOwnerVerifiesIt(t *testing.T) {
+	fixture := newVerifyFixture(t)
+
+	_, err := fixture.service.Invoke(&dto.VerifyTransactionDto{ID: "trx-1", Credential: alias.DefaultPin}, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixture.router.posted) != 1 {
+		t.Fatalf("the transaction should be posted once but was posted %d times", len(fixture.router.posted))
+	}
+}
+
+func TestVerifyTransactionService_Should_ReturnNotFound_When_TheTransactionBelongsToAnotherUser(t *testing.T) {
+	fixture := newVerifyFixture(t)
+	fixture.session.user = pinUser(janeID)
+
+	_, err := fixture.service.Invoke(&dto.VerifyTransactionDto{ID: "trx-1", Credential: alias.DefaultPin}, context.Background())
+	if err != alias.ErrMessageTransactionNotFound {
+		t.Fatalf("err should be `alias.ErrMessageTransactionNotFound` but was %v", err)
+	}
+	if len(fixture.router.posted) != 0 {
+		t.Fatal("the transaction of another user should not be posted")
+	}
+}
+
+func TestVerifyTransactionService_Should_KeepTheTransactionWaiting_When_ThePinIsWrong(t *testing.T) {
+	fixture := newVerifyFixture(t)
+
+	_, err := fixture.service.Invoke(&dto.VerifyTransactionDto{ID: "trx-1", Credential: "000000"}, context.Background())
+	if err != alias.ErrMessageInvalidCredential {
+		t.Fatalf("err should be `alias.ErrMessageInvalidCredential` but was %v", err)
+	}
+	transaction, err := fixture.transactions.FindByID(context.Background(), "trx-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.State != domain.WaitAuthorization || len(fixture.router.posted) != 0 {
+		t.Fatalf("the transaction should keep waiting for its authorization, got state %d", transaction.State)
+	}
+}
@@ -1,22 +1,31 @@
 package user
 
 import (
+	"log"
+
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
 	"github.com/tunaiku/mobilebanking/internal/app/user/repository/inmemory"
 	"github.com/tunaiku/mobilebanking/internal/app/user/service/fake"
+	"github.com/tunaiku/mobilebanking/internal/pkg/health"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
 	"go.uber.org/dig"
+	"go.uber.org/zap"
 )
 
 func Register(container *dig.Container) {
 	container.Provide(func() domain.UserRepository {
 		return inmemory.NewInMemoryUserRepository()
 	})
+	container.Provide(func() domain.UserAccountRepository {
+		return inmemory.NewInMemoryUserAccountRepository()
+	})
 	container.Provide(func(userRepository domain.UserRepository) domain.UserService {
 		return fake.NewFakeUserService(userRepository)
 	})
 
-	container.Provide(func(userRepository domain.UserRepository) domain.OtpCredentialManager {
-		return fake.NewFakeOtpCredentialManager(userRepository)
+	container.Provide(func(userRepository domain.UserRepository, metrics *metrics.Metrics,
+		logger *zap.Logger) domain.OtpCredentialManager {
+		return fake.NewFakeOtpCredentialManager(userRepository, metrics, logger)
 	})
 
 	container.Provide(func(userRepository domain.UserRepository) domain.PinCredentialManager {
@@ -25,5 +34,11 @@ func Register(container *dig.Container) {
 }
 
 func Invoke(container *dig.Container) {
-
+	err := container.Invoke(func(otpCredentialManager domain.OtpCredentialManager, healthRegistry *health.Registry) {
+		log.Println("invoke user startup ...")
+		healthRegistry.RegisterChecker("otp", otpCredentialManager)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
 }
@@ -49,6 +49,14 @@ func (inmem *InMemoryUserRepository) init() {
 			Username: "jane",
 			Password: string(password),
 		},
+		"9b2f5c1e-6a3d-4f7e-8c21-5d4a3b2e1f60": {
+			JoinDate: time.Now(),
+			ID:       "9b2f5c1e-6a3d-4f7e-8c21-5d4a3b2e1f60",
+			Name:     "Support Desk",
+			Username: "support",
+			Password: string(password),
+			Role:     domain.SupportRole,
+		},
 	}
 }
 
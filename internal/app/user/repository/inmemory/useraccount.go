@@ -0,0 +1,40 @@
+package inmemory
+
+import (
+	"github.com/tunaiku/mobilebanking/internal/app/domain"
+)
+
+type InMemoryUserAccountRepository struct {
+	datastore []domain.UserAccount
+}
+
+func NewInMemoryUserAccountRepository() *InMemoryUserAccountRepository {
+	return &InMemoryUserAccountRepository{
+		datastore: []domain.UserAccount{
+			{UserID: "fc55e3a8-c0fb-40c7-ab8a-9cda3fca40d4", AccountNumber: "10001", Primary: true},
+			{UserID: "fc55e3a8-c0fb-40c7-ab8a-9cda3fca40d4", AccountNumber: "20001"},
+			{UserID: "44c65528-950f-473f-ba69-00f28bc41f70", AccountNumber: "10002", Primary: true},
+			{UserID: "44c65528-950f-473f-ba69-00f28bc41f70", AccountNumber: "20001"},
+		},
+	}
+}
+
+func (inmem *InMemoryUserAccountRepository) FindAccountsByUser(userID string) ([]domain.UserAccount, error) {
+	var accounts []domain.UserAccount
+	for _, account := range inmem.datastore {
+		if account.UserID == userID {
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts, nil
+}
+
+func (inmem *InMemoryUserAccountRepository) FindUsersByAccount(accountNumber string) ([]domain.UserAccount, error) {
+	var users []domain.UserAccount
+	for _, account := range inmem.datastore {
+		if account.AccountNumber == accountNumber {
+			users = append(users, account)
+		}
+	}
+	return users, nil
+}
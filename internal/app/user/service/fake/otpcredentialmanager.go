@@ -1,9 +1,11 @@
 package fake
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/tunaiku/mobilebanking/internal/app/domain"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+	"go.uber.org/zap"
 )
 
 const (
@@ -12,10 +14,18 @@ const (
 
 type FakeOtpCredentialManager struct {
 	repository domain.UserRepository
+	metrics    *metrics.Metrics
+	logger     *zap.Logger
 }
 
-func NewFakeOtpCredentialManager(repository domain.UserRepository) *FakeOtpCredentialManager {
-	return &FakeOtpCredentialManager{repository: repository}
+func NewFakeOtpCredentialManager(repository domain.UserRepository, metrics *metrics.Metrics,
+	logger *zap.Logger) *FakeOtpCredentialManager {
+	return &FakeOtpCredentialManager{repository: repository, metrics: metrics, logger: logger}
+}
+
+// HealthCheck always succeeds, no SMS gateway is involved
+func (fake *FakeOtpCredentialManager) HealthCheck(ctx context.Context) error {
+	return nil
 }
 
 func (fake *FakeOtpCredentialManager) Validate(userId string, credential string) error {
@@ -40,7 +50,8 @@ func (fake *FakeOtpCredentialManager) RequestNewOtp(userId string) error {
 	if !user.ConfiguredTransactionCredential.IsOtpConfigured() {
 		return domain.ErrOtpNotConfigured
 	}
-	fmt.Println("generating otp for userId ", userId)
-	fmt.Println("your otp is ", DefaultOtp)
+	// the code itself is never logged, the fake always expects DefaultOtp
+	fake.logger.Info("otp sent", zap.String("user_id", userId))
+	fake.metrics.OtpSent()
 	return nil
 }
@@ -0,0 +1,87 @@
+package breaker
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrOpen = errors.New("circuit breaker is open")
+
+type state int
+
+const (
+	closed state = iota
+	open
+	halfOpen
+)
+
+// Breaker stops calling a failing dependency once threshold consecutive calls failed, after the cooldown
+// a single trial call is let through and its outcome closes or reopens the circuit.
+type Breaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    state
+	failures int
+	openedAt time.Time
+}
+
+func New(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{threshold: threshold, cooldown: cooldown}
+}
+
+// Allow returns ErrOpen while the circuit is open, otherwise the caller may proceed and must report
+// the outcome with Success or Failure
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case open:
+		if time.Now().Sub(b.openedAt) < b.cooldown {
+			return ErrOpen
+		}
+		b.state = halfOpen
+		return nil
+	case halfOpen:
+		return ErrOpen
+	default:
+		return nil
+	}
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.state = closed
+	b.failures = 0
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.state == halfOpen || b.failures >= b.threshold {
+		b.state = open
+		b.openedAt = time.Now()
+	}
+}
+
+// Abandon reports a call which ended without an outcome, such as one cancelled by its caller. It does not
+// count as a failure, a half-open circuit lets the next call through as the trial instead.
+func (b *Breaker) Abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == halfOpen {
+		b.state = open
+	}
+}
+
+// Open tells whether calls are currently rejected, without consuming the half-open trial call
+func (b *Breaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state == open && time.Now().Sub(b.openedAt) < b.cooldown
+}
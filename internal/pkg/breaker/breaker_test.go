@@ -0,0 +1,52 @@
+package breaker_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/breaker"
+)
+
+func TestBreaker_Should_Open_When_TheThresholdIsReached(t *testing.T) {
+	b := breaker.New(2, time.Minute)
+	b.Failure()
+	if err := b.Allow(); err != nil {
+		t.Fatal("breaker should still be closed")
+	}
+	b.Failure()
+	if err := b.Allow(); err != breaker.ErrOpen {
+		t.Fatal("breaker should be open")
+	}
+}
+
+func TestBreaker_Should_LetASingleTrialThrough_When_TheCooldownElapsed(t *testing.T) {
+	b := breaker.New(1, 10*time.Millisecond)
+	b.Failure()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := b.Allow(); err != nil {
+		t.Fatal("trial call should be allowed")
+	}
+	if err := b.Allow(); err != breaker.ErrOpen {
+		t.Fatal("only one trial call should be allowed")
+	}
+
+	b.Success()
+	if err := b.Allow(); err != nil {
+		t.Fatal("breaker should be closed after a successful trial")
+	}
+}
+
+func TestBreaker_Should_LetTheNextTrialThrough_When_TheTrialIsAbandoned(t *testing.T) {
+	b := breaker.New(1, 10*time.Millisecond)
+	b.Failure()
+
+	time.Sleep(20 * time.Millisecond)
+	if err := b.Allow(); err != nil {
+		t.Fatal("trial call should be allowed")
+	}
+	b.Abandon()
+	if err := b.Allow(); err != nil {
+		t.Fatal("another trial call should be allowed once the first one is abandoned")
+	}
+}
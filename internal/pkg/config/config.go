@@ -0,0 +1,421 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileEnv names the environment variable pointing to an optional YAML configuration file
+const FileEnv = "CONFIG_FILE"
+
+const redacted = "******"
+
+// defaultJWTSecret is the development secret of Default, it is refused outside development
+const defaultJWTSecret = "123456"
+
+const (
+	EnvDevelopment = "development"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+const (
+	LogFormatJSON    = "json"
+	LogFormatConsole = "console"
+)
+
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+const (
+	CoreBankingModeFake = "fake"
+	CoreBankingModeHTTP = "http"
+)
+
+const (
+	RateLimitBackendNone     = "none"
+	RateLimitBackendMemory   = "memory"
+	RateLimitBackendPostgres = "postgres"
+)
+
+var (
+	supportedEnvs       = map[string]bool{EnvDevelopment: true, EnvStaging: true, EnvProduction: true}
+	supportedAlgorithms = map[string]bool{"HS256": true, "HS384": true, "HS512": true}
+	supportedLogLevels  = map[string]bool{"debug": true, "info": true, "warn": true, "error": true}
+	supportedExporters  = map[string]bool{
+		TracingExporterNone: true, TracingExporterStdout: true, TracingExporterOTLP: true,
+	}
+	supportedRateLimitBackends = map[string]bool{
+		RateLimitBackendNone: true, RateLimitBackendMemory: true, RateLimitBackendPostgres: true,
+	}
+)
+
+type Config struct {
+	// Env is one of development, staging or production, only development may keep the development secrets
+	Env         string            `yaml:"env"`
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	JWT         JWTConfig         `yaml:"jwt"`
+	Log         LogConfig         `yaml:"log"`
+	Tracing     TracingConfig     `yaml:"tracing"`
+	RateLimit   RateLimitConfig   `yaml:"rateLimit"`
+	Clearing    ClearingConfig    `yaml:"clearing"`
+	CoreBanking CoreBankingConfig `yaml:"coreBanking"`
+}
+
+type ServerConfig struct {
+	Addr            string        `yaml:"addr"`
+	ReadTimeout     time.Duration `yaml:"readTimeout"`
+	WriteTimeout    time.Duration `yaml:"writeTimeout"`
+	IdleTimeout     time.Duration `yaml:"idleTimeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout"`
+	// DrainDelay keeps serving after the readiness turns false so the load balancer stops routing
+	// new requests before the server stops accepting them
+	DrainDelay time.Duration `yaml:"drainDelay"`
+}
+
+type DatabaseConfig struct {
+	Addr     string `yaml:"addr"`
+	Name     string `yaml:"name"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	PoolSize int    `yaml:"poolSize"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn or error
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"`
+}
+
+type TracingConfig struct {
+	// Exporter is one of none, stdout or otlp, none still propagates the trace context
+	Exporter string `yaml:"exporter"`
+	// Endpoint is the host:port of the OTLP gRPC collector
+	Endpoint    string `yaml:"endpoint"`
+	ServiceName string `yaml:"serviceName"`
+}
+
+type RateLimitConfig struct {
+	// Backend is one of none, memory or postgres, postgres shares the buckets between the instances
+	Backend string `yaml:"backend"`
+	// TrustForwardedFor keys the anonymous requests by the first X-Forwarded-For address, it is only
+	// safe behind a proxy which sets the header
+	TrustForwardedFor bool `yaml:"trustForwardedFor"`
+	// Groups holds the limit of every route group by name, a group left out is not limited
+	Groups map[string]RateLimitGroup `yaml:"groups"`
+}
+
+// RateLimitGroup allows Requests per Period on average, up to Burst of them at once
+type RateLimitGroup struct {
+	Requests int           `yaml:"requests"`
+	Period   time.Duration `yaml:"period"`
+	// Burst defaults to Requests
+	Burst int `yaml:"burst"`
+}
+
+type ClearingConfig struct {
+	// CallbackSecret is shared with the clearing to sign the settlement callbacks, it has no default
+	// so every environment serving them has to set its own
+	CallbackSecret string `yaml:"callbackSecret"`
+	// SettlementDelay is how long the simulated clearing takes to settle a transfer
+	SettlementDelay time.Duration `yaml:"settlementDelay"`
+	// FailureRate is the share of transfers the simulated clearing rejects, between 0 and 1
+	FailureRate float64 `yaml:"failureRate"`
+}
+
+type CoreBankingConfig struct {
+	// Mode is one of fake or http, the fake adapter keeps the service runnable without a core
+	Mode string `yaml:"mode"`
+	// URL is the base URL of the core banking API, used by the http mode
+	URL string `yaml:"url"`
+}
+
+type JWTConfig struct {
+	Algorithm string `yaml:"algorithm"`
+	Secret    string `yaml:"secret"`
+}
+
+// Default returns the configuration used for local development
+func Default() *Config {
+	return &Config{
+		Env: EnvDevelopment,
+		Server: ServerConfig{
+			Addr:            ":8080",
+			ReadTimeout:     15 * time.Second,
+			WriteTimeout:    15 * time.Second,
+			IdleTimeout:     60 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+			DrainDelay:      5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Addr:     "localhost:5432",
+			Name:     "mobile-banking-service",
+			User:     "postgres",
+			Password: "postgres",
+		},
+		JWT: JWTConfig{
+			Algorithm: "HS256",
+			Secret:    defaultJWTSecret,
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: LogFormatJSON,
+		},
+		Tracing: TracingConfig{
+			Exporter:    TracingExporterNone,
+			Endpoint:    "localhost:55680",
+			ServiceName: "mobilebanking",
+		},
+		RateLimit: RateLimitConfig{
+			Backend: RateLimitBackendMemory,
+			Groups: map[string]RateLimitGroup{
+				"auth":        {Requests: 10, Period: time.Minute},
+				"transaction": {Requests: 60, Period: time.Minute, Burst: 30},
+				"otp":         {Requests: 20, Period: time.Hour},
+			},
+		},
+		Clearing: ClearingConfig{
+			SettlementDelay: 5 * time.Second,
+			FailureRate:     0.1,
+		},
+		CoreBanking: CoreBankingConfig{
+			Mode: CoreBankingModeFake,
+			URL:  "http://localhost:8081",
+		},
+	}
+}
+
+// Load starts from the defaults, applies the YAML file named by CONFIG_FILE when set and then the
+// environment variables, so a variable always wins over the file
+func Load() (*Config, error) {
+	return load(os.LookupEnv)
+}
+
+func load(lookup func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+	if path, ok := lookup(FileEnv); ok && path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	if err := cfg.applyEnv(lookup); err != nil {
+		return nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (cfg *Config) readFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: read %s: %w", path, err)
+	}
+	if err := yaml.Unmarshal(content, cfg); err != nil {
+		return fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	return nil
+}
+
+func (cfg *Config) applyEnv(lookup func(string) (string, bool)) error {
+	texts := map[string]*string{
+		"APP_ENV":       &cfg.Env,
+		"SERVER_ADDR":   &cfg.Server.Addr,
+		"DB_ADDR":       &cfg.Database.Addr,
+		"DB_NAME":       &cfg.Database.Name,
+		"DB_USER":       &cfg.Database.User,
+		"DB_PASSWORD":   &cfg.Database.Password,
+		"JWT_ALGORITHM": &cfg.JWT.Algorithm,
+		"JWT_SECRET":    &cfg.JWT.Secret,
+		"LOG_LEVEL":     &cfg.Log.Level,
+		"LOG_FORMAT":    &cfg.Log.Format,
+
+		"TRACING_EXPORTER":      &cfg.Tracing.Exporter,
+		"TRACING_OTLP_ENDPOINT": &cfg.Tracing.Endpoint,
+		"TRACING_SERVICE_NAME":  &cfg.Tracing.ServiceName,
+
+		"RATE_LIMIT_BACKEND": &cfg.RateLimit.Backend,
+
+		"CLEARING_CALLBACK_SECRET": &cfg.Clearing.CallbackSecret,
+
+		"CORE_BANKING_MODE": &cfg.CoreBanking.Mode,
+		"CORE_BANKING_URL":  &cfg.CoreBanking.URL,
+	}
+	for name, field := range texts {
+		if value, ok := lookup(name); ok {
+			*field = value
+		}
+	}
+
+	durations := map[string]*time.Duration{
+		"SERVER_READ_TIMEOUT":     &cfg.Server.ReadTimeout,
+		"SERVER_WRITE_TIMEOUT":    &cfg.Server.WriteTimeout,
+		"SERVER_IDLE_TIMEOUT":     &cfg.Server.IdleTimeout,
+		"SERVER_SHUTDOWN_TIMEOUT": &cfg.Server.ShutdownTimeout,
+		"SERVER_DRAIN_DELAY":      &cfg.Server.DrainDelay,
+
+		"CLEARING_SETTLEMENT_DELAY": &cfg.Clearing.SettlementDelay,
+	}
+	for name, field := range durations {
+		value, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("config: %s: %w", name, err)
+		}
+		*field = duration
+	}
+
+	if value, ok := lookup("DB_POOL_SIZE"); ok {
+		var poolSize int
+		if _, err := fmt.Sscanf(value, "%d", &poolSize); err != nil {
+			return fmt.Errorf("config: DB_POOL_SIZE: %w", err)
+		}
+		cfg.Database.PoolSize = poolSize
+	}
+
+	if value, ok := lookup("RATE_LIMIT_TRUST_FORWARDED_FOR"); ok {
+		trust, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("config: RATE_LIMIT_TRUST_FORWARDED_FOR: %w", err)
+		}
+		cfg.RateLimit.TrustForwardedFor = trust
+	}
+
+	if value, ok := lookup("CLEARING_FAILURE_RATE"); ok {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("config: CLEARING_FAILURE_RATE: %w", err)
+		}
+		cfg.Clearing.FailureRate = rate
+	}
+	return nil
+}
+
+// Validate reports every missing or invalid setting at once
+func (cfg *Config) Validate() error {
+	var problems []string
+	if !supportedEnvs[cfg.Env] {
+		problems = append(problems, fmt.Sprintf("environment %q is not supported", cfg.Env))
+	}
+	if cfg.Server.Addr == "" {
+		problems = append(problems, "server address is required")
+	}
+	if cfg.Server.ReadTimeout < 0 || cfg.Server.WriteTimeout < 0 || cfg.Server.IdleTimeout < 0 {
+		problems = append(problems, "server timeouts must not be negative")
+	}
+	if cfg.Server.ShutdownTimeout <= 0 {
+		problems = append(problems, "server shutdown timeout must be positive")
+	}
+	if cfg.Server.DrainDelay < 0 {
+		problems = append(problems, "server drain delay must not be negative")
+	}
+	if cfg.Database.Addr == "" {
+		problems = append(problems, "database address is required")
+	}
+	if cfg.Database.Name == "" {
+		problems = append(problems, "database name is required")
+	}
+	if cfg.Database.User == "" {
+		problems = append(problems, "database user is required")
+	}
+	if cfg.Database.PoolSize < 0 {
+		problems = append(problems, "database pool size must not be negative")
+	}
+	if !supportedAlgorithms[cfg.JWT.Algorithm] {
+		problems = append(problems, fmt.Sprintf("jwt algorithm %q is not supported", cfg.JWT.Algorithm))
+	}
+	if cfg.JWT.Secret == "" {
+		problems = append(problems, "jwt secret is required")
+	} else if cfg.JWT.Secret == defaultJWTSecret && cfg.Env != EnvDevelopment {
+		problems = append(problems, "jwt secret must not be the development default outside development")
+	}
+	if !supportedLogLevels[cfg.Log.Level] {
+		problems = append(problems, fmt.Sprintf("log level %q is not supported", cfg.Log.Level))
+	}
+	if cfg.Log.Format != LogFormatJSON && cfg.Log.Format != LogFormatConsole {
+		problems = append(problems, fmt.Sprintf("log format %q is not supported", cfg.Log.Format))
+	}
+	if !supportedExporters[cfg.Tracing.Exporter] {
+		problems = append(problems, fmt.Sprintf("tracing exporter %q is not supported", cfg.Tracing.Exporter))
+	}
+	if cfg.Tracing.Exporter == TracingExporterOTLP && cfg.Tracing.Endpoint == "" {
+		problems = append(problems, "tracing endpoint is required by the otlp exporter")
+	}
+	if cfg.Tracing.ServiceName == "" {
+		problems = append(problems, "tracing service name is required")
+	}
+	if !supportedRateLimitBackends[cfg.RateLimit.Backend] {
+		problems = append(problems, fmt.Sprintf("rate limit backend %q is not supported", cfg.RateLimit.Backend))
+	}
+	groups := make([]string, 0, len(cfg.RateLimit.Groups))
+	for name := range cfg.RateLimit.Groups {
+		groups = append(groups, name)
+	}
+	sort.Strings(groups)
+	for _, name := range groups {
+		group := cfg.RateLimit.Groups[name]
+		if group.Requests <= 0 || group.Period <= 0 || group.Burst < 0 {
+			problems = append(problems, fmt.Sprintf("rate limit of group %q needs positive requests and period", name))
+		}
+	}
+	if cfg.Clearing.SettlementDelay < 0 {
+		problems = append(problems, "clearing settlement delay must not be negative")
+	}
+	if cfg.Clearing.FailureRate < 0 || cfg.Clearing.FailureRate > 1 {
+		problems = append(problems, "clearing failure rate must be between 0 and 1")
+	}
+	if cfg.CoreBanking.Mode != CoreBankingModeFake && cfg.CoreBanking.Mode != CoreBankingModeHTTP {
+		problems = append(problems, fmt.Sprintf("core banking mode %q is not supported", cfg.CoreBanking.Mode))
+	}
+	if cfg.CoreBanking.Mode == CoreBankingModeHTTP && cfg.CoreBanking.URL == "" {
+		problems = append(problems, "core banking url is required by the http mode")
+	}
+	if len(problems) > 0 {
+		return errors.New("config: " + strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// RequireCallbackSecret reports a missing callback secret, only the processes serving the settlement
+// callbacks need one so it is not part of Validate
+func (clearing ClearingConfig) RequireCallbackSecret() error {
+	if clearing.CallbackSecret == "" {
+		return errors.New("config: clearing callback secret is required")
+	}
+	return nil
+}
+
+// String prints the configuration with the secrets redacted so it can safely be logged
+func (cfg Config) String() string {
+	cfg.Database.Password = redact(cfg.Database.Password)
+	cfg.JWT.Secret = redact(cfg.JWT.Secret)
+	cfg.Clearing.CallbackSecret = redact(cfg.Clearing.CallbackSecret)
+	content, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err.Error()
+	}
+	return string(content)
+}
+
+func redact(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
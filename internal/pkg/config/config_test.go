@@ -0,0 +1,156 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+)
+
+func setEnv(t *testing.T, values map[string]string) {
+	for name, value := range values {
+		os.Setenv(name, value)
+	}
+	t.Cleanup(func() {
+		for name := range values {
+			os.Unsetenv(name)
+		}
+	})
+}
+
+func TestLoad_Should_ReturnTheDefaults_When_OnlyTheSecretsAreConfigured(t *testing.T) {
+	setEnv(t, map[string]string{"CLEARING_CALLBACK_SECRET": "callback-secret"})
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Addr != ":8080" || cfg.Database.Name != "mobile-banking-service" || cfg.JWT.Algorithm != "HS256" {
+		t.Fatalf("unexpected defaults %+v", cfg)
+	}
+}
+
+func TestLoad_Should_PreferTheEnvironment_When_TheFileSetsTheSameValue(t *testing.T) {
+	file, err := ioutil.TempFile("", "config-*.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	content := "server:\n  addr: \":9090\"\n  readTimeout: 3s\ndatabase:\n  name: from-file\n  user: file-user\n"
+	if _, err := file.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+	setEnv(t, map[string]string{config.FileEnv: file.Name(), "DB_USER": "env-user",
+		"CLEARING_CALLBACK_SECRET": "callback-secret"})
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Server.Addr != ":9090" || cfg.Server.ReadTimeout != 3*time.Second || cfg.Database.Name != "from-file" {
+		t.Fatalf("file values not applied %+v", cfg)
+	}
+	if cfg.Database.User != "env-user" {
+		t.Fatalf("expected the environment to win, got %s", cfg.Database.User)
+	}
+}
+
+func TestLoad_Should_Fail_When_TheConfigurationIsInvalid(t *testing.T) {
+	setEnv(t, map[string]string{"JWT_SECRET": "", "JWT_ALGORITHM": "none", "SERVER_WRITE_TIMEOUT": "soon"})
+
+	if _, err := config.Load(); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestValidate_Should_ReportEveryProblem_When_SeveralSettingsAreMissing(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Name = ""
+	cfg.JWT.Secret = ""
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "database name") || !strings.Contains(err.Error(), "jwt secret") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestString_Should_RedactSecrets_When_Printed(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.Password = "db-password"
+	cfg.JWT.Secret = "jwt-secret"
+	cfg.Clearing.CallbackSecret = "callback-secret"
+
+	printed := cfg.String()
+	if strings.Contains(printed, "db-password") || strings.Contains(printed, "jwt-secret") ||
+		strings.Contains(printed, "callback-secret") {
+		t.Fatalf("secrets leaked in %s", printed)
+	}
+	if cfg.Database.Password != "db-password" {
+		t.Fatal("printing must not change the configuration")
+	}
+}
+
+func TestLoad_Should_Fail_When_TheOtlpExporterHasNoEndpoint(t *testing.T) {
+	setEnv(t, map[string]string{"TRACING_EXPORTER": config.TracingExporterOTLP, "TRACING_OTLP_ENDPOINT": ""})
+
+	_, err := config.Load()
+	if err == nil || !strings.Contains(err.Error(), "tracing endpoint") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestValidate_Should_RejectTheRateLimitGroup_When_ItHasNoPeriod(t *testing.T) {
+	cfg := config.Default()
+	cfg.RateLimit.Groups["otp"] = config.RateLimitGroup{Requests: 5}
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), `group "otp"`) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestLoad_Should_NotRequireTheCallbackSecret_When_TheProcessDoesNotServeTheCallbacks(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cfg.Clearing.RequireCallbackSecret()
+	if err == nil || !strings.Contains(err.Error(), "clearing callback secret") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestLoad_Should_Fail_When_TheClearingFailureRateIsAboveOne(t *testing.T) {
+	setEnv(t, map[string]string{"CLEARING_CALLBACK_SECRET": "callback-secret", "CLEARING_FAILURE_RATE": "1.5"})
+
+	_, err := config.Load()
+	if err == nil || !strings.Contains(err.Error(), "clearing failure rate") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestLoad_Should_Fail_When_TheDefaultJwtSecretIsUsedOutsideDevelopment(t *testing.T) {
+	setEnv(t, map[string]string{"CLEARING_CALLBACK_SECRET": "callback-secret", "APP_ENV": config.EnvProduction})
+
+	_, err := config.Load()
+	if err == nil || !strings.Contains(err.Error(), "jwt secret") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestLoad_Should_ReadTheCoreBankingSettings_When_TheEnvironmentSetsThem(t *testing.T) {
+	setEnv(t, map[string]string{"CLEARING_CALLBACK_SECRET": "callback-secret",
+		"CORE_BANKING_MODE": config.CoreBankingModeHTTP, "CORE_BANKING_URL": "http://core:8081"})
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.CoreBanking.Mode != config.CoreBankingModeHTTP || cfg.CoreBanking.URL != "http://core:8081" {
+		t.Fatalf("core banking settings not applied %+v", cfg.CoreBanking)
+	}
+}
@@ -0,0 +1,18 @@
+package config
+
+import (
+	"log"
+
+	"go.uber.org/dig"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func() (*Config, error) {
+		cfg, err := Load()
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("configuration loaded:\n%s", cfg)
+		return cfg, nil
+	})
+}
@@ -0,0 +1,50 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/render"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+)
+
+type Endpoint struct {
+	registry        *Registry
+	serverLifecycle *lifecycle.Lifecycle
+}
+
+func NewEndpoint(registry *Registry, serverLifecycle *lifecycle.Lifecycle) *Endpoint {
+	return &Endpoint{registry: registry, serverLifecycle: serverLifecycle}
+}
+
+// BindRoutes exposes the probes, they are left unauthenticated for the orchestrator
+func (endpoint *Endpoint) BindRoutes(r chi.Router) {
+	r.Get("/healthz", endpoint.HandleLiveness)
+	r.Get("/readyz", endpoint.HandleReadiness)
+}
+
+// HandleLiveness only tells whether the process is running, dependencies are left to the readiness
+// probe so an outage of one of them does not get the pods restarted
+func (endpoint *Endpoint) HandleLiveness(w http.ResponseWriter, r *http.Request) {
+	if !endpoint.serverLifecycle.Live() {
+		render.Status(r, http.StatusServiceUnavailable)
+		render.JSON(w, r, Report{Status: StatusDown})
+		return
+	}
+	render.JSON(w, r, Report{Status: StatusUp})
+}
+
+// HandleReadiness probes every registered component, a server not serving yet or draining is never ready
+func (endpoint *Endpoint) HandleReadiness(w http.ResponseWriter, r *http.Request) {
+	if state := endpoint.serverLifecycle.State(); state == lifecycle.Draining || state == lifecycle.Stopped {
+		render.Status(r, http.StatusServiceUnavailable)
+		render.JSON(w, r, Report{Status: state.String()})
+		return
+	}
+
+	report := endpoint.registry.Run(r.Context())
+	if report.Status != StatusUp {
+		render.Status(r, http.StatusServiceUnavailable)
+	}
+	render.JSON(w, r, report)
+}
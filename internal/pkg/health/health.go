@@ -0,0 +1,126 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check reports an error when the component it probes is not usable
+type Check func(ctx context.Context) error
+
+// Checker is implemented by the adapters able to probe the system behind them
+type Checker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+type ComponentStatus struct {
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latencyMs"`
+}
+
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+// Registry keeps the readiness checks contributed by the modules
+type Registry struct {
+	mutex   sync.RWMutex
+	checks  map[string]Check
+	timeout time.Duration
+}
+
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{checks: map[string]Check{}, timeout: timeout}
+}
+
+// Register adds the check under the component name, registering the same name again replaces it
+func (registry *Registry) Register(name string, check Check) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	registry.checks[name] = check
+}
+
+// RegisterChecker adds the checker when the given adapter implements one, it tells whether it did
+func (registry *Registry) RegisterChecker(name string, adapter interface{}) bool {
+	checker, ok := adapter.(Checker)
+	if ok {
+		registry.Register(name, checker.HealthCheck)
+	}
+	return ok
+}
+
+func (registry *Registry) Names() []string {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	names := make([]string, 0, len(registry.checks))
+	for name := range registry.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run probes every component concurrently, each check gets the registry timeout at most
+func (registry *Registry) Run(ctx context.Context) Report {
+	registry.mutex.RLock()
+	checks := make(map[string]Check, len(registry.checks))
+	for name, check := range registry.checks {
+		checks[name] = check
+	}
+	registry.mutex.RUnlock()
+
+	type result struct {
+		name   string
+		status ComponentStatus
+	}
+	results := make(chan result, len(checks))
+	for name, check := range checks {
+		go func(name string, check Check) {
+			results <- result{name: name, status: registry.run(ctx, check)}
+		}(name, check)
+	}
+
+	report := Report{Status: StatusUp, Components: make(map[string]ComponentStatus, len(checks))}
+	for range checks {
+		result := <-results
+		report.Components[result.name] = result.status
+		if result.status.Status != StatusUp {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+func (registry *Registry) run(ctx context.Context, check Check) ComponentStatus {
+	ctx, cancel := context.WithTimeout(ctx, registry.timeout)
+	defer cancel()
+
+	started := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	status := ComponentStatus{Status: StatusUp, LatencyMs: time.Since(started).Milliseconds()}
+	if err != nil {
+		status.Status = StatusDown
+		status.Error = err.Error()
+	}
+	return status
+}
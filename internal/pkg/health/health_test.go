@@ -0,0 +1,93 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/health"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+)
+
+type adapter struct {
+	err error
+}
+
+func (a adapter) HealthCheck(ctx context.Context) error {
+	return a.err
+}
+
+func TestRegistry_Should_ReportEveryComponent_When_OneIsDown(t *testing.T) {
+	registry := health.NewRegistry(time.Second)
+	registry.RegisterChecker("savings", adapter{})
+	registry.RegisterChecker("otp", adapter{err: errors.New("gateway unreachable")})
+
+	report := registry.Run(context.Background())
+	if report.Status != health.StatusDown {
+		t.Fatalf("expected down, got %s", report.Status)
+	}
+	if report.Components["savings"].Status != health.StatusUp {
+		t.Fatal("savings should be up")
+	}
+	if otp := report.Components["otp"]; otp.Status != health.StatusDown || otp.Error != "gateway unreachable" {
+		t.Fatalf("unexpected otp status %+v", otp)
+	}
+}
+
+func TestRegistry_Should_GiveUp_When_ACheckHangs(t *testing.T) {
+	registry := health.NewRegistry(10 * time.Millisecond)
+	release := make(chan struct{})
+	defer close(release)
+	registry.Register("postgres", func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	report := registry.Run(context.Background())
+	if report.Components["postgres"].Status != health.StatusDown {
+		t.Fatal("a hanging check should be reported down")
+	}
+}
+
+func TestRegistry_Should_SkipTheAdapter_When_ItCannotBeChecked(t *testing.T) {
+	registry := health.NewRegistry(time.Second)
+	if registry.RegisterChecker("savings", struct{}{}) {
+		t.Fatal("an adapter without HealthCheck must not be registered")
+	}
+	if len(registry.Names()) != 0 {
+		t.Fatal("no check expected")
+	}
+}
+
+func TestEndpoint_Should_ReturnServiceUnavailable_When_AComponentIsDown(t *testing.T) {
+	registry := health.NewRegistry(time.Second)
+	registry.Register("postgres", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+	router := chi.NewRouter()
+	health.NewEndpoint(registry, lifecycle.New(&http.Server{}, time.Second, 0)).BindRoutes(router)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", recorder.Code)
+	}
+	report := health.Report{}
+	if err := json.NewDecoder(recorder.Body).Decode(&report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Components["postgres"].Error != "connection refused" {
+		t.Fatalf("unexpected report %+v", report)
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("liveness must not depend on the components, got %d", recorder.Code)
+	}
+}
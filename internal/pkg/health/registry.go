@@ -0,0 +1,32 @@
+package health
+
+import (
+	"log"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+	"go.uber.org/dig"
+)
+
+// CheckTimeout bounds every readiness check so a hanging dependency cannot hang the probe
+const CheckTimeout = 2 * time.Second
+
+func Register(container *dig.Container) {
+	container.Provide(func() *Registry {
+		return NewRegistry(CheckTimeout)
+	})
+	container.Provide(func(registry *Registry, serverLifecycle *lifecycle.Lifecycle) *Endpoint {
+		return NewEndpoint(registry, serverLifecycle)
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(router chi.Router, endpoint *Endpoint, registry *Registry) {
+		log.Println("invoke health startup ... checking", registry.Names())
+		endpoint.BindRoutes(router)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
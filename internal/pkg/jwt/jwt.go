@@ -1,19 +1,33 @@
 package jwt
 
-import "github.com/dgrijalva/jwt-go"
-
-var (
-	Algorithm = "HS256"
-
-	Secret = []byte("123456")
+import (
+	"github.com/dgrijalva/jwt-go"
+	"github.com/go-chi/jwtauth"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
 )
 
 type ClaimsMapper func() jwt.Claims
 
-func CreateTokenString(mapper ClaimsMapper) (token string, err error) {
-	mapClaims := mapper
-	sign := jwt.New(jwt.GetSigningMethod(Algorithm))
-	sign.Claims = mapClaims()
-	token, err = sign.SignedString(Secret)
+// Authority signs the access tokens and verifies them on the protected routes with the configured
+// algorithm and secret
+type Authority struct {
+	algorithm string
+	secret    []byte
+	tokenAuth *jwtauth.JWTAuth
+}
+
+func NewAuthority(cfg config.JWTConfig) *Authority {
+	secret := []byte(cfg.Secret)
+	return &Authority{
+		algorithm: cfg.Algorithm,
+		secret:    secret,
+		tokenAuth: jwtauth.New(cfg.Algorithm, secret, nil),
+	}
+}
+
+func (authority *Authority) CreateTokenString(mapper ClaimsMapper) (token string, err error) {
+	sign := jwt.New(jwt.GetSigningMethod(authority.algorithm))
+	sign.Claims = mapper()
+	token, err = sign.SignedString(authority.secret)
 	return
 }
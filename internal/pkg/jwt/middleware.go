@@ -1,13 +1,27 @@
 package jwt
 
 import (
+	"net/http"
+
 	"github.com/go-chi/chi"
 	"github.com/go-chi/jwtauth"
+	"github.com/tunaiku/mobilebanking/internal/pkg/logger"
 )
 
-func WrapChiRouterWithAuthorization(r chi.Router) chi.Router {
-	tokenAuth := jwtauth.New(Algorithm, Secret, nil)
-	r.Use(jwtauth.Verifier(tokenAuth))
+func (authority *Authority) WrapChiRouterWithAuthorization(r chi.Router) chi.Router {
+	r.Use(jwtauth.Verifier(authority.tokenAuth))
 	r.Use(jwtauth.Authenticator)
+	r.Use(logAuthenticatedUser)
 	return r
 }
+
+// logAuthenticatedUser adds the subject of the verified token to the request logs
+func logAuthenticatedUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, claims, err := jwtauth.FromContext(r.Context())
+		if subject, ok := claims["sub"].(string); err == nil && ok {
+			r = r.WithContext(logger.WithUserID(r.Context(), subject))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,12 @@
+package jwt
+
+import (
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"go.uber.org/dig"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func(cfg *config.Config) *Authority {
+		return NewAuthority(cfg.JWT)
+	})
+}
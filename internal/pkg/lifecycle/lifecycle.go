@@ -0,0 +1,173 @@
+package lifecycle
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+type State int32
+
+const (
+	Starting State = iota
+	Ready
+	Draining
+	Stopped
+)
+
+func (state State) String() string {
+	switch state {
+	case Starting:
+		return "starting"
+	case Ready:
+		return "ready"
+	case Draining:
+		return "draining"
+	default:
+		return "stopped"
+	}
+}
+
+// Hook releases a resource on shutdown, it should give up once the context is done
+type Hook func(ctx context.Context) error
+
+type stopHook struct {
+	name string
+	hook Hook
+}
+
+// Lifecycle runs the HTTP server until a termination signal arrives, then reports it is not ready for
+// the drain delay, drains the in-flight requests and runs the stop hooks within the shutdown timeout
+type Lifecycle struct {
+	server          *http.Server
+	shutdownTimeout time.Duration
+	drainDelay      time.Duration
+	signals         []os.Signal
+	state           int32
+	mutex           sync.Mutex
+	hooks           []stopHook
+}
+
+func New(server *http.Server, shutdownTimeout time.Duration, drainDelay time.Duration) *Lifecycle {
+	return &Lifecycle{
+		server:          server,
+		shutdownTimeout: shutdownTimeout,
+		drainDelay:      drainDelay,
+		signals:         []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+	}
+}
+
+// OnStop registers a hook run after the server is drained, hooks run in registration order
+// so the resources the others depend on, like the database, should be registered last
+func (lifecycle *Lifecycle) OnStop(name string, hook Hook) {
+	lifecycle.mutex.Lock()
+	defer lifecycle.mutex.Unlock()
+	lifecycle.hooks = append(lifecycle.hooks, stopHook{name: name, hook: hook})
+}
+
+func (lifecycle *Lifecycle) State() State {
+	return State(atomic.LoadInt32(&lifecycle.state))
+}
+
+// Live tells whether the process is still able to make progress, it only turns false once stopped
+func (lifecycle *Lifecycle) Live() bool {
+	return lifecycle.State() != Stopped
+}
+
+// Ready tells whether new requests should be routed to the server
+func (lifecycle *Lifecycle) Ready() bool {
+	return lifecycle.State() == Ready
+}
+
+func (lifecycle *Lifecycle) setState(state State) {
+	atomic.StoreInt32(&lifecycle.state, int32(state))
+}
+
+// Run serves until SIGINT or SIGTERM is received and then shuts down gracefully
+func (lifecycle *Lifecycle) Run() error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, lifecycle.signals...)
+	defer signal.Stop(signals)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		select {
+		case received := <-signals:
+			log.Println("received", received, "shutting down ...")
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return lifecycle.Serve(ctx)
+}
+
+// Serve serves until the context is done and then shuts down gracefully
+func (lifecycle *Lifecycle) Serve(ctx context.Context) error {
+	listener, err := net.Listen("tcp", lifecycle.server.Addr)
+	if err != nil {
+		lifecycle.setState(Stopped)
+		return err
+	}
+
+	served := make(chan error, 1)
+	go func() {
+		served <- lifecycle.server.Serve(listener)
+	}()
+	lifecycle.setState(Ready)
+	log.Println("server listening on", listener.Addr())
+
+	select {
+	case err = <-served:
+		// the server stopped on its own, the resources are still released
+	case <-ctx.Done():
+	}
+	if shutdownErr := lifecycle.Shutdown(); err == nil {
+		err = shutdownErr
+	}
+	if err == http.ErrServerClosed {
+		err = nil
+	}
+	return err
+}
+
+// Shutdown reports the server is not ready and keeps serving for the drain delay, then stops accepting
+// requests, waits for the in-flight ones and runs the stop hooks. Everything after the drain delay has to
+// finish within the shutdown timeout.
+func (lifecycle *Lifecycle) Shutdown() error {
+	lifecycle.setState(Draining)
+	if lifecycle.drainDelay > 0 {
+		log.Println("waiting", lifecycle.drainDelay, "for the load balancer to stop routing requests ...")
+		time.Sleep(lifecycle.drainDelay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lifecycle.shutdownTimeout)
+	defer cancel()
+
+	err := lifecycle.server.Shutdown(ctx)
+	if err != nil {
+		log.Println("draining requests failed:", err)
+	}
+
+	lifecycle.mutex.Lock()
+	hooks := lifecycle.hooks
+	lifecycle.mutex.Unlock()
+	for _, stop := range hooks {
+		log.Println("stopping", stop.name, "...")
+		if hookErr := stop.hook(ctx); hookErr != nil {
+			log.Println("stopping", stop.name, "failed:", hookErr)
+			if err == nil {
+				err = hookErr
+			}
+		}
+	}
+
+	lifecycle.setState(Stopped)
+	return err
+}
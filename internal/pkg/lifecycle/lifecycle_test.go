@@ -0,0 +1,124 @@
+package lifecycle_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+)
+
+func waitForState(t *testing.T, serverLifecycle *lifecycle.Lifecycle, state lifecycle.State) {
+	deadline := time.Now().Add(time.Second)
+	for serverLifecycle.State() != state {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected state %s, got %s", state, serverLifecycle.State())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLifecycle_Should_DrainInFlightRequestsAndRunHooksInOrder_When_Stopped(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	})
+	server := &http.Server{Addr: "127.0.0.1:18089", Handler: mux}
+	serverLifecycle := lifecycle.New(server, time.Second, 0)
+
+	var stopped []string
+	serverLifecycle.OnStop("worker", func(ctx context.Context) error {
+		stopped = append(stopped, "worker")
+		return nil
+	})
+	serverLifecycle.OnStop("database", func(ctx context.Context) error {
+		stopped = append(stopped, "database")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serverLifecycle.Serve(ctx)
+	}()
+	waitForState(t, serverLifecycle, lifecycle.Ready)
+
+	status := make(chan int, 1)
+	go func() {
+		response, err := http.Get("http://127.0.0.1:18089/slow")
+		if err != nil {
+			status <- 0
+			return
+		}
+		response.Body.Close()
+		status <- response.StatusCode
+	}()
+	<-started
+
+	cancel()
+	waitForState(t, serverLifecycle, lifecycle.Draining)
+	if serverLifecycle.Ready() {
+		t.Fatal("a draining server must not be ready")
+	}
+	close(release)
+
+	if code := <-status; code != http.StatusOK {
+		t.Fatalf("in-flight request was not drained, got %d", code)
+	}
+	if err := <-served; err != nil {
+		t.Fatal(err)
+	}
+	if serverLifecycle.Live() {
+		t.Fatal("a stopped server must not be live")
+	}
+	if len(stopped) != 2 || stopped[0] != "worker" || stopped[1] != "database" {
+		t.Fatalf("unexpected hook order %v", stopped)
+	}
+}
+
+func TestLifecycle_Should_ReportTheHookError_When_AHookFails(t *testing.T) {
+	serverLifecycle := lifecycle.New(&http.Server{Addr: "127.0.0.1:0"}, time.Second, 0)
+	serverLifecycle.OnStop("worker", func(ctx context.Context) error {
+		return context.DeadlineExceeded
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := serverLifecycle.Serve(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected the hook error, got %v", err)
+	}
+}
+
+func TestLifecycle_Should_KeepServingWhileNotReady_When_TheDrainDelayRuns(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ping", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	serverLifecycle := lifecycle.New(&http.Server{Addr: "127.0.0.1:18090", Handler: mux}, time.Second, 200*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- serverLifecycle.Serve(ctx)
+	}()
+	waitForState(t, serverLifecycle, lifecycle.Ready)
+
+	cancel()
+	waitForState(t, serverLifecycle, lifecycle.Draining)
+	response, err := http.Get("http://127.0.0.1:18090/ping")
+	if err != nil {
+		t.Fatalf("the server should accept requests during the drain delay, got %v", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", response.StatusCode)
+	}
+	if err := <-served; err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,22 @@
+package lifecycle
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"go.uber.org/dig"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func(cfg *config.Config, router chi.Router) *Lifecycle {
+		server := &http.Server{
+			Addr:         cfg.Server.Addr,
+			Handler:      router,
+			ReadTimeout:  cfg.Server.ReadTimeout,
+			WriteTimeout: cfg.Server.WriteTimeout,
+			IdleTimeout:  cfg.Server.IdleTimeout,
+		}
+		return New(server, cfg.Server.ShutdownTimeout, cfg.Server.DrainDelay)
+	})
+}
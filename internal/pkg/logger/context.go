@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+type loggerKey struct{}
+
+type accessKey struct{}
+
+// access collects what the handlers learn about the request for its access log line
+type access struct {
+	userID string
+}
+
+func WithContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the request logger, carrying the request id, or the global logger outside a request
+func FromContext(ctx context.Context) *zap.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return zap.L()
+}
+
+// WithUserID records the authenticated user for the access log and adds it to the request logger
+func WithUserID(ctx context.Context, userID string) context.Context {
+	if entry, ok := ctx.Value(accessKey{}).(*access); ok {
+		entry.userID = userID
+	}
+	return WithContext(ctx, FromContext(ctx).With(zap.String("user_id", userID)))
+}
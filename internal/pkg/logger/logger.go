@@ -0,0 +1,134 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/mask"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// New builds the service logger, every entry it writes goes through the masking core
+func New(cfg config.LogConfig) (*zap.Logger, error) {
+	var zapConfig zap.Config
+	if cfg.Format == config.LogFormatConsole {
+		zapConfig = zap.NewDevelopmentConfig()
+	} else {
+		zapConfig = zap.NewProductionConfig()
+		zapConfig.EncoderConfig.TimeKey = "time"
+		zapConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	}
+	if err := zapConfig.Level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, err
+	}
+	return zapConfig.Build(zap.WrapCore(NewMaskingCore))
+}
+
+// maskingCore masks the message and the fields of the entries before they reach the encoder, the fields are
+// masked by their key first and by their content otherwise. Structured fields are masked the same way down
+// to their nested keys.
+type maskingCore struct {
+	zapcore.Core
+}
+
+func NewMaskingCore(core zapcore.Core) zapcore.Core {
+	return maskingCore{core}
+}
+
+func (core maskingCore) With(fields []zapcore.Field) zapcore.Core {
+	return maskingCore{core.Core.With(maskFields(fields))}
+}
+
+func (core maskingCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if core.Enabled(entry.Level) {
+		return checked.AddCore(entry, core)
+	}
+	return checked
+}
+
+func (core maskingCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	entry.Message = mask.Text(entry.Message)
+	return core.Core.Write(entry, maskFields(fields))
+}
+
+func maskFields(fields []zapcore.Field) []zapcore.Field {
+	masked := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		switch field.Type {
+		case zapcore.StringType:
+			field.String = mask.Field(field.Key, field.String)
+		case zapcore.ByteStringType:
+			if value, ok := field.Interface.([]byte); ok {
+				field = zap.String(field.Key, mask.Field(field.Key, string(value)))
+			}
+		case zapcore.ErrorType:
+			if err, ok := field.Interface.(error); ok {
+				field = zap.String(field.Key, mask.Text(err.Error()))
+			}
+		case zapcore.StringerType:
+			if stringer, ok := field.Interface.(fmt.Stringer); ok {
+				field = zap.String(field.Key, mask.Field(field.Key, stringer.String()))
+			}
+		case zapcore.ReflectType:
+			field = maskStructured(field.Key, field.Interface)
+		case zapcore.ObjectMarshalerType, zapcore.ArrayMarshalerType:
+			encoder := zapcore.NewMapObjectEncoder()
+			field.AddTo(encoder)
+			field = maskStructured(field.Key, encoder.Fields[field.Key])
+		}
+		masked[i] = field
+	}
+	return masked
+}
+
+// maskStructured masks the value as it would be encoded in JSON, a value which can not be encoded is logged as
+// masked text
+func maskStructured(key string, value interface{}) zapcore.Field {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return zap.String(key, mask.Text(fmt.Sprintf("%+v", value)))
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return zap.String(key, mask.Text(string(encoded)))
+	}
+	return zap.Any(key, maskValue([]string{key}, decoded))
+}
+
+// maskValue masks the strings by every key they are nested under, the numbers only when one of the keys names
+// sensitive data
+func maskValue(keys []string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case string:
+		return maskString(keys, value)
+	case json.Number:
+		if masked := maskString(keys, value.String()); masked != value.String() {
+			return masked
+		}
+		return value
+	case map[string]interface{}:
+		for nested, item := range value {
+			value[nested] = maskValue(append(keys[:len(keys):len(keys)], nested), item)
+		}
+		return value
+	case []interface{}:
+		for i, item := range value {
+			value[i] = maskValue(keys, item)
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+func maskString(keys []string, value string) string {
+	for _, key := range keys {
+		value = mask.Field(key, value)
+	}
+	return value
+}
@@ -0,0 +1,137 @@
+package logger_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newObservedLogger() (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	return zap.New(logger.NewMaskingCore(core)), logs
+}
+
+func TestMaskingCore_Should_MaskSensitiveFieldsAndMessages_When_Logging(t *testing.T) {
+	appLogger, logs := newObservedLogger()
+	appLogger.With(zap.String("pin", "123456")).Info("your otp is 111111",
+		zap.String("source_account", "10001234"),
+		zap.String("phone", "081234567890"),
+		zap.Error(errors.New("password=secret rejected")),
+		zap.String("transaction_code", "T001"))
+
+	entry := logs.All()[0]
+	if entry.Message != "your otp is ******" {
+		t.Fatalf("message not masked: %s", entry.Message)
+	}
+	fields := entry.ContextMap()
+	expected := map[string]string{
+		"pin":              "******",
+		"source_account":   "****1234",
+		"phone":            "*********890",
+		"error":            "password=****** rejected",
+		"transaction_code": "T001",
+	}
+	for key, value := range expected {
+		if fields[key] != value {
+			t.Errorf("%s: expected `%s` but got `%v`", key, value, fields[key])
+		}
+	}
+}
+
+type transfer struct {
+	SourceAccount      string
+	DestinationAccount int
+	Amount             float64
+	Pin                string
+}
+
+func (transfer transfer) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+	encoder.AddString("source_account", transfer.SourceAccount)
+	encoder.AddInt("destination_account", transfer.DestinationAccount)
+	encoder.AddFloat64("amount", transfer.Amount)
+	return nil
+}
+
+func TestMaskingCore_Should_MaskTheNestedFields_When_AStructIsLogged(t *testing.T) {
+	appLogger, logs := newObservedLogger()
+	value := transfer{SourceAccount: "10001234", DestinationAccount: 20005678, Amount: 50000, Pin: "123456"}
+	appLogger.Info("transfer requested",
+		zap.Any("transfer", []transfer{value}),
+		zap.Any("account_number", map[string]string{"primary": "10001234"}),
+		zap.Object("object", value))
+
+	fields := logs.All()[0].ContextMap()
+	expected := map[string]string{
+		"transfer":       `[{"Amount":50000,"DestinationAccount":"****5678","Pin":"******","SourceAccount":"****1234"}]`,
+		"account_number": `{"primary":"****1234"}`,
+		"object":         `{"amount":50000,"destination_account":"****5678","source_account":"****1234"}`,
+	}
+	for key, value := range expected {
+		encoded, err := json.Marshal(fields[key])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(encoded) != value {
+			t.Errorf("%s: expected `%s` but got `%s`", key, value, encoded)
+		}
+	}
+	if value.SourceAccount != "10001234" {
+		t.Fatal("masking must not change the logged value")
+	}
+}
+
+func TestMiddleware_Should_LogOneAccessLineWithTheRequestID_When_ARequestIsServed(t *testing.T) {
+	appLogger, logs := newObservedLogger()
+	router := chi.NewRouter()
+	router.Use(logger.RequestID(appLogger), logger.AccessLog)
+	router.Get("/transaction/{id}", func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(logger.WithUserID(r.Context(), "user-1"))
+		logger.FromContext(r.Context()).Info("loading transaction")
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/transaction/42", nil)
+	request.Header.Set(logger.RequestIDHeader, "req-1")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+
+	if recorder.Header().Get(logger.RequestIDHeader) != "req-1" {
+		t.Fatal("the request id should be echoed")
+	}
+	handlerLine := logs.FilterMessage("loading transaction").All()[0].ContextMap()
+	if handlerLine["request_id"] != "req-1" || handlerLine["user_id"] != "user-1" {
+		t.Fatalf("handler logs should carry the request and user, got %v", handlerLine)
+	}
+	access := logs.FilterMessage("request served").All()
+	if len(access) != 1 {
+		t.Fatalf("expected one access line, got %d", len(access))
+	}
+	fields := access[0].ContextMap()
+	if fields["route"] != "/transaction/{id}" || fields["status"] != int64(http.StatusAccepted) ||
+		fields["user_id"] != "user-1" || fields["request_id"] != "req-1" {
+		t.Fatalf("unexpected access line %v", fields)
+	}
+}
+
+func TestRequestID_Should_AssignAnID_When_TheCallerSentNoneOrAnInvalidOne(t *testing.T) {
+	appLogger, _ := newObservedLogger()
+	handler := logger.RequestID(appLogger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for _, sent := range []string{"", "contains spaces"} {
+		request := httptest.NewRequest(http.MethodGet, "/", nil)
+		request.Header.Set(logger.RequestIDHeader, sent)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		if assigned := recorder.Header().Get(logger.RequestIDHeader); assigned == "" || assigned == sent {
+			t.Fatalf("expected a generated request id instead of `%s`, got `%s`", sent, assigned)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package logger
+
+import (
+	"log"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"go.uber.org/dig"
+	"go.uber.org/zap"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func(cfg *config.Config) (*zap.Logger, error) {
+		return New(cfg.Log)
+	})
+}
+
+// Invoke makes the logger global and routes the standard library logger through it, so the remaining
+// log.Println calls are structured and masked too, it has to run before the other modules
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(logger *zap.Logger) {
+		zap.ReplaceGlobals(logger)
+		zap.RedirectStdLog(logger)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
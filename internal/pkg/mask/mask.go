@@ -0,0 +1,91 @@
+package mask
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Name keeps the first letter of every word of the name and hides the rest,
+// "John Doe" becomes "J*** D**".
+func Name(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		runes := []rune(word)
+		words[i] = string(runes[0]) + strings.Repeat("*", len(runes)-1)
+	}
+	return strings.Join(words, " ")
+}
+
+const redacted = "******"
+
+// Secret hides the whole value, it is meant for passwords, PINs, OTPs and tokens.
+func Secret(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	return redacted
+}
+
+// Digits keeps the last visible characters of an account or phone number, "081234567890" becomes
+// "********7890" with 4 visible characters.
+func Digits(value string, visible int) string {
+	runes := []rune(value)
+	if len(runes) <= visible {
+		return strings.Repeat("*", len(runes))
+	}
+	return strings.Repeat("*", len(runes)-visible) + string(runes[len(runes)-visible:])
+}
+
+var (
+	secretKeys  = []string{"password", "pin", "otp", "token", "secret", "credential", "authorization"}
+	accountKeys = []string{"account"}
+	phoneKeys   = []string{"phone", "msisdn"}
+
+	// secretAssignment catches the secrets written as `otp=123456`, `password: x` or `otp is 123456`
+	secretAssignment = regexp.MustCompile(`(?i)\b(password|pin|otp|token|secret|credential)(\s*[:=]\s*|\s+is\s+)("[^"]*"|\S+)`)
+	// accountAssignment catches the account numbers written next to their name
+	accountAssignment = regexp.MustCompile(`(?i)\b(account(?:[ _]?(?:number|no))?)(\s*[:=]\s*|\s+)(\d{4,})`)
+	bearerToken       = regexp.MustCompile(`(?i)\bbearer\s+\S+`)
+	jsonWebToken      = regexp.MustCompile(`\beyJ[\w-]*\.[\w-]*\.[\w-]*`)
+	phoneNumber       = regexp.MustCompile(`(?:\+62|\b62|\b0)8\d{7,11}\b`)
+)
+
+// Field masks a value according to the name it is logged under, it returns the value untouched when
+// the name tells nothing and Text finds nothing sensitive in it.
+func Field(key string, value string) string {
+	lower := strings.ToLower(key)
+	switch {
+	case containsAny(lower, secretKeys):
+		return Secret(value)
+	case containsAny(lower, accountKeys):
+		return Digits(value, 4)
+	case containsAny(lower, phoneKeys):
+		return Digits(value, 3)
+	default:
+		return Text(value)
+	}
+}
+
+// Text masks the sensitive values recognizable in free text: secrets and account numbers written
+// next to their name, bearer and JSON web tokens and Indonesian phone numbers.
+func Text(text string) string {
+	text = secretAssignment.ReplaceAllString(text, "${1}${2}"+redacted)
+	text = accountAssignment.ReplaceAllStringFunc(text, func(match string) string {
+		groups := accountAssignment.FindStringSubmatch(match)
+		return groups[1] + groups[2] + Digits(groups[3], 4)
+	})
+	text = bearerToken.ReplaceAllString(text, "Bearer "+redacted)
+	text = jsonWebToken.ReplaceAllString(text, redacted)
+	return phoneNumber.ReplaceAllStringFunc(text, func(match string) string {
+		return Digits(match, 3)
+	})
+}
+
+func containsAny(value string, candidates []string) bool {
+	for _, candidate := range candidates {
+		if strings.Contains(value, candidate) {
+			return true
+		}
+	}
+	return false
+}
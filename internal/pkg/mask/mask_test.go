@@ -0,0 +1,47 @@
+package mask_test
+
+import (
+	"testing"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/mask"
+)
+
+func TestName_Should_KeepTheFirstLetterOfEveryWord(t *testing.T) {
+	if masked := mask.Name("John  Doe"); masked != "J*** D**" {
+		t.Fatalf("masked name should be `J*** D**` but got `%s`", masked)
+	}
+}
+
+func TestField_Should_MaskTheValue_When_TheKeyNamesSensitiveData(t *testing.T) {
+	cases := map[string][2]string{
+		"password":           {"p4ssw0rd", "******"},
+		"otp":                {"111111", "******"},
+		"access_token":       {"eyJhbGciOi", "******"},
+		"source_account":     {"10001234", "****1234"},
+		"phone_number":       {"081234567890", "*********890"},
+		"transaction_code":   {"T001", "T001"},
+		"destinationAccount": {"20001", "*0001"},
+	}
+	for key, values := range cases {
+		if masked := mask.Field(key, values[0]); masked != values[1] {
+			t.Errorf("%s: expected `%s` but got `%s`", key, values[1], masked)
+		}
+	}
+}
+
+func TestText_Should_MaskSecretsAccountsTokensAndPhones_When_TheyAppearInAMessage(t *testing.T) {
+	cases := map[string]string{
+		"your otp is 111111":                          "your otp is ******",
+		"login with password=secret failed":           "login with password=****** failed",
+		"transfer from account 10001234 accepted":     "transfer from account ****1234 accepted",
+		"Authorization: Bearer abc.def.ghi":           "Authorization: Bearer ******",
+		"token eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIx.sig": "token ******",
+		"sms sent to +6281234567890":                  "sms sent to ***********890",
+		"scheduled transaction 42 failed":             "scheduled transaction 42 failed",
+	}
+	for text, expected := range cases {
+		if masked := mask.Text(text); masked != expected {
+			t.Errorf("expected `%s` but got `%s`", expected, masked)
+		}
+	}
+}
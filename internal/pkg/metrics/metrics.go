@@ -0,0 +1,141 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "mobilebanking"
+
+	VerificationSucceeded = "succeeded"
+	VerificationFailed    = "failed"
+
+	// unmatchedRoute labels the requests no route matched, so unknown paths do not explode the cardinality
+	unmatchedRoute = "unmatched"
+)
+
+// Metrics holds the collectors of the service on a registry of its own rather than the global one
+type Metrics struct {
+	registry            *prometheus.Registry
+	requests            *prometheus.CounterVec
+	latency             *prometheus.HistogramVec
+	transactionsCreated *prometheus.CounterVec
+	verifications       *prometheus.CounterVec
+	otpsSent            prometheus.Counter
+	loginFailures       prometheus.Counter
+}
+
+func New() *Metrics {
+	metrics := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests handled, by method, route pattern and status.",
+		}, []string{"method", "route", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency, by method, route pattern and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		transactionsCreated: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transactions_created_total",
+			Help:      "Transactions created, by transaction code and first authorization method.",
+		}, []string{"transaction_code", "auth_method"}),
+		verifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "transaction_verifications_total",
+			Help:      "Transaction verifications, by result.",
+		}, []string{"result"}),
+		otpsSent: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "otps_sent_total",
+			Help:      "One time passwords sent to the users.",
+		}),
+		loginFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "login_failures_total",
+			Help:      "Authentications rejected because of a wrong username or password.",
+		}),
+	}
+	metrics.registry.MustRegister(
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+		metrics.requests,
+		metrics.latency,
+		metrics.transactionsCreated,
+		metrics.verifications,
+		metrics.otpsSent,
+		metrics.loginFailures,
+	)
+	return metrics
+}
+
+// Register adds a collector contributed by a module, registering the same collector twice is not an error
+func (metrics *Metrics) Register(collector prometheus.Collector) error {
+	err := metrics.registry.Register(collector)
+	if _, ok := err.(prometheus.AlreadyRegisteredError); ok {
+		return nil
+	}
+	return err
+}
+
+// RegisterGaugeFunc exposes a gauge whose value is read from the function on every scrape
+func (metrics *Metrics) RegisterGaugeFunc(name string, help string, function func() float64) error {
+	return metrics.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      name,
+		Help:      help,
+	}, function))
+}
+
+func (metrics *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware records every request under its chi route pattern, it has to be installed on the root router
+func (metrics *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r)
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		route := unmatchedRoute
+		if routeContext := chi.RouteContext(r.Context()); routeContext != nil && routeContext.RoutePattern() != "" {
+			route = routeContext.RoutePattern()
+		}
+		labels := prometheus.Labels{"method": r.Method, "route": route, "status": strconv.Itoa(status)}
+		metrics.requests.With(labels).Inc()
+		metrics.latency.With(labels).Observe(time.Since(started).Seconds())
+	})
+}
+
+func (metrics *Metrics) TransactionCreated(transactionCode string, authMethod string) {
+	metrics.transactionsCreated.WithLabelValues(transactionCode, authMethod).Inc()
+}
+
+// Verification counts a verification attempt, result is VerificationSucceeded or VerificationFailed
+func (metrics *Metrics) Verification(result string) {
+	metrics.verifications.WithLabelValues(result).Inc()
+}
+
+func (metrics *Metrics) OtpSent() {
+	metrics.otpsSent.Inc()
+}
+
+func (metrics *Metrics) LoginFailed() {
+	metrics.loginFailures.Inc()
+}
@@ -0,0 +1,77 @@
+package metrics_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+)
+
+func scrape(t *testing.T, appMetrics *metrics.Metrics) string {
+	recorder := httptest.NewRecorder()
+	appMetrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body, err := ioutil.ReadAll(recorder.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(body)
+}
+
+func assertContains(t *testing.T, exposition string, line string) {
+	if !strings.Contains(exposition, line) {
+		t.Fatalf("expected %q in\n%s", line, exposition)
+	}
+}
+
+func TestMiddleware_Should_LabelRequestsWithTheRoutePattern_When_ARouteMatches(t *testing.T) {
+	appMetrics := metrics.New()
+	router := chi.NewRouter()
+	router.Use(appMetrics.Middleware)
+	router.Get("/transaction/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/transaction/abc", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown/path", nil))
+
+	exposition := scrape(t, appMetrics)
+	assertContains(t, exposition, `mobilebanking_http_requests_total{method="GET",route="/transaction/{id}",status="404"} 1`)
+	assertContains(t, exposition, `mobilebanking_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assertContains(t, exposition, `mobilebanking_http_request_duration_seconds_count{method="GET",route="/transaction/{id}",status="404"} 1`)
+}
+
+func TestMetrics_Should_CountBusinessEvents_When_TheyAreRecorded(t *testing.T) {
+	appMetrics := metrics.New()
+	appMetrics.TransactionCreated("T001", "otp")
+	appMetrics.Verification(metrics.VerificationFailed)
+	appMetrics.OtpSent()
+	appMetrics.LoginFailed()
+	appMetrics.LoginFailed()
+
+	exposition := scrape(t, appMetrics)
+	assertContains(t, exposition, `mobilebanking_transactions_created_total{auth_method="otp",transaction_code="T001"} 1`)
+	assertContains(t, exposition, `mobilebanking_transaction_verifications_total{result="failed"} 1`)
+	assertContains(t, exposition, `mobilebanking_otps_sent_total 1`)
+	assertContains(t, exposition, `mobilebanking_login_failures_total 2`)
+}
+
+func TestRegister_Should_Succeed_When_TheSameCollectorIsRegisteredTwice(t *testing.T) {
+	appMetrics := metrics.New()
+	newGauge := func() prometheus.Collector {
+		return prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: "pending", Help: "pending"}, func() float64 {
+			return 3
+		})
+	}
+	if err := appMetrics.Register(newGauge()); err != nil {
+		t.Fatal(err)
+	}
+	if err := appMetrics.Register(newGauge()); err != nil {
+		t.Fatalf("registering again should be tolerated, got %v", err)
+	}
+	assertContains(t, scrape(t, appMetrics), "pending 3")
+}
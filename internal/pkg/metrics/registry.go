@@ -0,0 +1,25 @@
+package metrics
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/dig"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func() *Metrics {
+		return New()
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(router chi.Router, metrics *Metrics) {
+		log.Println("invoke metrics startup ...")
+		router.Method(http.MethodGet, "/metrics", metrics.Handler())
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
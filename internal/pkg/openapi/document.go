@@ -0,0 +1,187 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+
+	bearerAuth = "bearerAuth"
+	jsonMedia  = "application/json"
+)
+
+var pathParameter = regexp.MustCompile(`{([^}]+)}`)
+
+// ErrorResponse is the envelope every failure is answered with
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// Response documents one status of an operation, Body is a value of the payload type or nil when the
+// response has no JSON body
+type Response struct {
+	Description string
+	Body        interface{}
+}
+
+// Operation documents a route, the schemas are generated from the payload types so they follow the
+// structs the handler binds and renders
+type Operation struct {
+	ID      string
+	Method  string
+	Path    string
+	Summary string
+	Tag     string
+	// Secured operations expect the JWT returned by the authentication as a bearer token
+	Secured bool
+	// Request is a value of the type the body is bound to, nil when the operation takes no body
+	Request   interface{}
+	Responses map[int]Response
+}
+
+// Document collects the operations the modules document into an OpenAPI specification
+type Document struct {
+	title   string
+	version string
+
+	mu         sync.RWMutex
+	operations []Operation
+}
+
+func NewDocument(title, version string) *Document {
+	return &Document{title: title, version: version}
+}
+
+// Add documents the operations, an operation added again under the same ID replaces the previous one
+func (document *Document) Add(operations ...Operation) {
+	document.mu.Lock()
+	defer document.mu.Unlock()
+	for _, operation := range operations {
+		replaced := false
+		for i := range document.operations {
+			if document.operations[i].ID == operation.ID {
+				document.operations[i] = operation
+				replaced = true
+			}
+		}
+		if !replaced {
+			document.operations = append(document.operations, operation)
+		}
+	}
+}
+
+func (document *Document) Operations() []Operation {
+	document.mu.RLock()
+	defer document.mu.RUnlock()
+	return append([]Operation(nil), document.operations...)
+}
+
+func (document *Document) MarshalJSON() ([]byte, error) {
+	generator := newGenerator()
+	paths := make(map[string]map[string]interface{})
+	for _, operation := range document.Operations() {
+		if paths[operation.Path] == nil {
+			paths[operation.Path] = make(map[string]interface{})
+		}
+		paths[operation.Path][strings.ToLower(operation.Method)] = operation.spec(generator)
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info":    map[string]string{"title": document.title, "version": document.version},
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": generator.components,
+			"securitySchemes": map[string]interface{}{
+				bearerAuth: map[string]string{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"},
+			},
+		},
+	})
+}
+
+func (operation Operation) spec(generator *generator) map[string]interface{} {
+	spec := map[string]interface{}{"operationId": operation.ID, "summary": operation.Summary}
+	if operation.Tag != "" {
+		spec["tags"] = []string{operation.Tag}
+	}
+	if operation.Secured {
+		spec["security"] = []map[string][]string{{bearerAuth: {}}}
+	}
+	if parameters := operation.parameters(); len(parameters) > 0 {
+		spec["parameters"] = parameters
+	}
+	if operation.Request != nil {
+		spec["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  content(generator.schemaOf(operation.Request, false)),
+		}
+	}
+
+	responses := make(map[string]interface{}, len(operation.Responses))
+	for status, response := range operation.Responses {
+		description := response.Description
+		if description == "" {
+			description = http.StatusText(status)
+		}
+		spec := map[string]interface{}{"description": description}
+		if response.Body != nil {
+			spec["content"] = content(generator.schemaOf(response.Body, true))
+		}
+		responses[strconv.Itoa(status)] = spec
+	}
+	spec["responses"] = responses
+	return spec
+}
+
+func (operation Operation) parameters() []map[string]interface{} {
+	var parameters []map[string]interface{}
+	for _, match := range pathParameter.FindAllStringSubmatch(operation.Path, -1) {
+		parameters = append(parameters, map[string]interface{}{
+			"name":     match[1],
+			"in":       "path",
+			"required": true,
+			"schema":   &Schema{Type: "string"},
+		})
+	}
+	return parameters
+}
+
+// Route is the method and path of a route, as bound on the router or documented by an operation
+type Route struct {
+	Method string
+	Path   string
+}
+
+func (route Route) String() string {
+	return route.Method + " " + route.Path
+}
+
+// Routes lists the routes of the operations sorted by path then method
+func Routes(operations []Operation) []Route {
+	routes := make([]Route, 0, len(operations))
+	for _, operation := range operations {
+		routes = append(routes, Route{Method: operation.Method, Path: operation.Path})
+	}
+	SortRoutes(routes)
+	return routes
+}
+
+func SortRoutes(routes []Route) {
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return routes[i].Method < routes[j].Method
+	})
+}
+
+func content(schema *Schema) map[string]interface{} {
+	return map[string]interface{}{jsonMedia: map[string]interface{}{"schema": schema}}
+}
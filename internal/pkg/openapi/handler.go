@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"text/template"
+
+	"github.com/go-chi/chi"
+)
+
+const (
+	SpecPath = "/openapi.json"
+	DocsPath = "/docs"
+
+	swaggerUIVersion = "3.52.5"
+)
+
+var swaggerUI = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "{{.SpecPath}}", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`))
+
+// Handler serves the specification as JSON
+func (document *Document) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		spec, err := json.Marshal(document)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", jsonMedia)
+		w.Write(spec)
+	})
+}
+
+// UIHandler serves a Swagger UI page browsing the specification
+func (document *Document) UIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		swaggerUI.Execute(w, map[string]string{
+			"Title":    document.title,
+			"Version":  swaggerUIVersion,
+			"SpecPath": SpecPath,
+		})
+	})
+}
+
+// BoundRoutes walks the router for the routes it serves, comparing them with Routes of the documented
+// operations keeps the specification from drifting away from the handlers
+func BoundRoutes(router chi.Routes) ([]Route, error) {
+	var routes []Route
+	err := chi.Walk(router, func(method string, route string, handler http.Handler,
+		middlewares ...func(http.Handler) http.Handler) error {
+		routes = append(routes, Route{Method: method, Path: route})
+		return nil
+	})
+	SortRoutes(routes)
+	return routes, err
+}
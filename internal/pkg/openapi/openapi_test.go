@@ -0,0 +1,188 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
+)
+
+type transferItem struct {
+	Account string  `json:"account" required:"true"`
+	Amount  float64 `json:"amount" required:"true"`
+}
+
+type transferRequest struct {
+	Code          string            `json:"code" required:"true"`
+	Items         []transferItem    `json:"items" required:"true"`
+	ExecutionDate *time.Time        `json:"execution_date"`
+	Labels        map[string]string `json:"labels"`
+	ClientIP      string            `json:"-"`
+}
+
+type transferResponse struct {
+	ID            string `json:"id"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+var transferOperation = openapi.Operation{
+	ID:      "transfer",
+	Method:  http.MethodPost,
+	Path:    "/transfer/{id}",
+	Secured: true,
+	Request: transferRequest{},
+	Responses: map[int]openapi.Response{
+		http.StatusCreated:    {Body: transferResponse{}},
+		http.StatusBadRequest: {Body: openapi.ErrorResponse{}},
+	},
+}
+
+type spec struct {
+	OpenAPI    string `json:"openapi"`
+	Paths      map[string]map[string]json.RawMessage
+	Components struct {
+		Schemas map[string]openapi.Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+func marshal(t *testing.T, document *openapi.Document) spec {
+	body, err := json.Marshal(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result spec
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func required(schema openapi.Schema) []string {
+	names := append([]string(nil), schema.Required...)
+	sort.Strings(names)
+	return names
+}
+
+func TestDocument_Should_GenerateTheSchemasFromThePayloadTypes_When_MarshaledToJSON(t *testing.T) {
+	document := openapi.NewDocument("test", "1")
+	document.Add(transferOperation)
+
+	result := marshal(t, document)
+	if result.OpenAPI != "3.0.3" || result.Paths["/transfer/{id}"]["post"] == nil {
+		t.Fatalf("expected the operation under its path, got %v", result.Paths)
+	}
+
+	request := result.Components.Schemas["transferRequest"]
+	if got := required(request); !reflect.DeepEqual(got, []string{"code", "items"}) {
+		t.Fatalf("expected the tagged request fields to be required, got %v", got)
+	}
+	if _, ok := request.Properties["ClientIP"]; ok {
+		t.Fatal("expected the field ignored by encoding/json to be left out")
+	}
+	if date := request.Properties["execution_date"]; date.Format != "date-time" || !date.Nullable {
+		t.Fatalf("expected a nullable date-time, got %+v", date)
+	}
+	if items := request.Properties["items"]; items.Items.Ref != "#/components/schemas/transferItem" {
+		t.Fatalf("expected the items to refer to their component, got %+v", items.Items)
+	}
+	if labels := request.Properties["labels"]; labels.AdditionalProperties.Type != "string" {
+		t.Fatalf("expected a map of strings, got %+v", labels)
+	}
+
+	response := result.Components.Schemas["transferResponse"]
+	if got := required(response); !reflect.DeepEqual(got, []string{"id"}) {
+		t.Fatalf("expected the response fields which are never omitted to be required, got %v", got)
+	}
+}
+
+func TestDocument_Should_ReplaceTheOperation_When_ItIsAddedAgain(t *testing.T) {
+	document := openapi.NewDocument("test", "1")
+	document.Add(transferOperation)
+	document.Add(transferOperation)
+
+	if operations := document.Operations(); len(operations) != 1 {
+		t.Fatalf("expected a single operation, got %d", len(operations))
+	}
+}
+
+func TestValidateRequest_Should_RejectTheBody_When_ItDoesNotMatchTheSchema(t *testing.T) {
+	handler := openapi.ValidateRequest(transferOperation)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("the handler should not be reached")
+	}))
+
+	for name, body := range map[string]string{
+		"missing field":  `{"items": []}`,
+		"mistyped field": `{"code": "T001", "items": [{"account": "10002", "amount": "3000"}]}`,
+		"invalid date":   `{"code": "T001", "items": [], "execution_date": "tomorrow"}`,
+		"malformed":      `{"code": `,
+		"empty":          ``,
+	} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transfer/1", strings.NewReader(body)))
+
+		var response openapi.ErrorResponse
+		json.NewDecoder(recorder.Body).Decode(&response)
+		if recorder.Code != http.StatusBadRequest || !strings.HasPrefix(response.Message, "invalid request body") {
+			t.Fatalf("%s: expected 400 with the violations, got %d %q", name, recorder.Code, response.Message)
+		}
+	}
+}
+
+func TestValidateRequest_Should_PassTheBodyOnToTheHandler_When_ItMatchesTheSchema(t *testing.T) {
+	body := `{"code": "T001", "items": [{"account": "10002", "amount": 3000}], "execution_date": null, "extra": 1}`
+	var received string
+	handler := openapi.ValidateRequest(transferOperation)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		read, _ := ioutil.ReadAll(r.Body)
+		received = string(read)
+	}))
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/transfer/1", strings.NewReader(body)))
+
+	if recorder.Code != http.StatusOK || received != body {
+		t.Fatalf("expected the handler to read the body, got %d %q", recorder.Code, received)
+	}
+}
+
+func TestBoundRoutes_Should_ListTheRoutesOfTheRouter_When_Walked(t *testing.T) {
+	router := chi.NewRouter()
+	router.Group(func(r chi.Router) {
+		r.Post("/transfer/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	})
+
+	routes, err := openapi.BoundRoutes(router)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if documented := openapi.Routes([]openapi.Operation{transferOperation}); !reflect.DeepEqual(routes, documented) {
+		t.Fatalf("expected %v, got %v", documented, routes)
+	}
+}
+
+func TestDocument_Should_ServeTheSpecificationAndSwaggerUI_When_Requested(t *testing.T) {
+	document := openapi.NewDocument("test", "1")
+	document.Add(transferOperation)
+	router := chi.NewRouter()
+	router.Method(http.MethodGet, openapi.SpecPath, document.Handler())
+	router.Method(http.MethodGet, openapi.DocsPath, document.UIHandler())
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), `"/transfer/{id}"`) {
+		t.Fatalf("expected the specification, got %d %s", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), `url: "/openapi.json"`) {
+		t.Fatalf("expected the Swagger UI page, got %d %s", recorder.Code, recorder.Body.String())
+	}
+}
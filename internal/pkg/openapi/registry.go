@@ -0,0 +1,31 @@
+package openapi
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"go.uber.org/dig"
+)
+
+const (
+	Title      = "Mobile Banking API"
+	APIVersion = "1.0.0"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func() *Document {
+		return NewDocument(Title, APIVersion)
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(router chi.Router, document *Document) {
+		log.Println("invoke openapi startup ...")
+		router.Method(http.MethodGet, SpecPath, document.Handler())
+		router.Method(http.MethodGet, DocsPath, document.UIHandler())
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
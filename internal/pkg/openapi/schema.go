@@ -0,0 +1,165 @@
+package openapi
+
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const componentsPrefix = "#/components/schemas/"
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	bigFloatType = reflect.TypeOf(big.Float{})
+)
+
+// Schema is the part of the OpenAPI 3.0 schema object the payloads need
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// generator turns the payload types into schemas, every struct becomes a component named after its
+// type and is referenced from where it is used
+type generator struct {
+	components map[string]*Schema
+}
+
+func newGenerator() *generator {
+	return &generator{components: make(map[string]*Schema)}
+}
+
+// schemaOf generates the schema of the payload, a request field is required when tagged
+// `required:"true"` while a response field is required unless it is omitted when empty
+func (generator *generator) schemaOf(payload interface{}, response bool) *Schema {
+	return generator.schema(reflect.TypeOf(payload), response)
+}
+
+func (generator *generator) schema(t reflect.Type, response bool) *Schema {
+	if t.Kind() == reflect.Ptr {
+		schema := generator.schema(t.Elem(), response)
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == bigFloatType:
+		return &Schema{Type: "number"}
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if _, ok := generator.components[t.Name()]; !ok {
+			// registered before the fields so a recursive type refers to itself
+			generator.components[t.Name()] = &Schema{}
+			*generator.components[t.Name()] = *generator.object(t, response)
+		}
+		return &Schema{Ref: componentsPrefix + t.Name()}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: generator.schema(t.Elem(), response)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: generator.schema(t.Elem(), response)}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+	return &Schema{}
+}
+
+// object lists the fields the way encoding/json marshals them, embedded structs are flattened
+func (generator *generator) object(t reflect.Type, response bool) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, omitEmpty, skip := jsonName(field)
+		if skip {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct && name == "" {
+			embedded := generator.object(field.Type, response)
+			for property, propertySchema := range embedded.Properties {
+				schema.Properties[property] = propertySchema
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = generator.schema(field.Type, response)
+		if field.Tag.Get("required") == "true" || (response && !omitEmpty) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return schema
+}
+
+func jsonName(field reflect.StructField) (name string, omitEmpty bool, skip bool) {
+	if field.PkgPath != "" && !field.Anonymous {
+		return "", false, true
+	}
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	parts := strings.Split(tag, ",")
+	for _, option := range parts[1:] {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return parts[0], omitEmpty, false
+}
+
+// jsonSchema inlines the references and turns the OpenAPI nullable flag into a null type, the
+// result can be compiled by a JSON Schema validator
+func (generator *generator) jsonSchema(schema *Schema) map[string]interface{} {
+	if schema.Ref != "" {
+		return generator.jsonSchema(generator.components[strings.TrimPrefix(schema.Ref, componentsPrefix)])
+	}
+	out := make(map[string]interface{})
+	if schema.Type != "" {
+		if schema.Nullable {
+			out["type"] = []string{schema.Type, "null"}
+		} else {
+			out["type"] = schema.Type
+		}
+	}
+	if schema.Format != "" {
+		out["format"] = schema.Format
+	}
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]interface{}, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = generator.jsonSchema(property)
+		}
+		out["properties"] = properties
+	}
+	if len(schema.Required) > 0 {
+		out["required"] = schema.Required
+	}
+	if schema.Items != nil {
+		out["items"] = generator.jsonSchema(schema.Items)
+	}
+	if schema.AdditionalProperties != nil {
+		out["additionalProperties"] = generator.jsonSchema(schema.AdditionalProperties)
+	}
+	return out
+}
@@ -0,0 +1,58 @@
+package openapi
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/render"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// maxBodyBytes bounds the body read before it is validated, the payloads are a few hundred bytes
+const maxBodyBytes = 1 << 20
+
+// ValidateRequest checks the body against the request schema of the operation before the handler
+// binds it, an invalid body is answered with 400 and the violations. The schema only describes the
+// shape of the payload, the business rules are still checked by the services. It panics when the
+// schema cannot be compiled since the payload type is then wrong
+func ValidateRequest(operation Operation) func(http.Handler) http.Handler {
+	generator := newGenerator()
+	schema, err := gojsonschema.NewSchema(gojsonschema.NewGoLoader(
+		generator.jsonSchema(generator.schemaOf(operation.Request, false))))
+	if err != nil {
+		panic("openapi: request schema of " + operation.ID + ": " + err.Error())
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+			if err != nil {
+				invalidRequest(w, r, err.Error())
+				return
+			}
+			r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+			result, err := schema.Validate(gojsonschema.NewBytesLoader(body))
+			if err != nil {
+				invalidRequest(w, r, err.Error())
+				return
+			}
+			if !result.Valid() {
+				violations := make([]string, 0, len(result.Errors()))
+				for _, violation := range result.Errors() {
+					violations = append(violations, violation.String())
+				}
+				invalidRequest(w, r, strings.Join(violations, "; "))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func invalidRequest(w http.ResponseWriter, r *http.Request, reason string) {
+	render.Status(r, http.StatusBadRequest)
+	render.JSON(w, r, &ErrorResponse{Message: "invalid request body: " + reason})
+}
@@ -1,12 +1,21 @@
 package pg
 
 import (
+	"context"
+
 	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
 )
 
+type transactional interface {
+	orm.DB
+	RunInTransaction(fn func(*pg.Tx) error) error
+}
+
 // Refactoring crud repository
 type CrudRepositoryWrapper struct {
-	db *pg.DB
+	db  transactional
+	ctx context.Context
 }
 
 var pgDb *pg.DB
@@ -15,23 +24,58 @@ func Wrap(db *pg.DB) *CrudRepositoryWrapper {
 	if db == nil {
 		db = pgDb
 	}
-	return &CrudRepositoryWrapper{db: db}
+	return &CrudRepositoryWrapper{db: db, ctx: context.Background()}
+}
+
+// FromContext returns a wrapper whose queries are cancelled with the context, they run in the
+// unit of work the context carries if any.
+func FromContext(ctx context.Context) *CrudRepositoryWrapper {
+	if tx, ok := ctx.Value(txKey{}).(*pg.Tx); ok {
+		return &CrudRepositoryWrapper{db: tx, ctx: ctx}
+	}
+	return &CrudRepositoryWrapper{db: pgDb, ctx: ctx}
 }
 
 func (wrapper *CrudRepositoryWrapper) Save(model interface{}) error {
-	_, err := wrapper.db.Model(model).OnConflict("(id) DO UPDATE").Insert(model)
+	_, err := wrapper.db.ModelContext(wrapper.ctx, model).OnConflict("(id) DO UPDATE").Insert(model)
+	return err
+}
+
+// Insert appends the model without touching existing rows, used by append-only tables.
+func (wrapper *CrudRepositoryWrapper) Insert(model interface{}) error {
+	_, err := wrapper.db.ModelContext(wrapper.ctx, model).Insert(model)
 	return err
 }
 
 func (wrapper *CrudRepositoryWrapper) Load(model interface{}) error {
-	err := wrapper.db.Model(model).WherePK().Select()
+	err := wrapper.db.ModelContext(wrapper.ctx, model).WherePK().Select()
 	return err
 }
 
 func (wrapper *CrudRepositoryWrapper) Remove(model interface{}) error {
-	_, err := wrapper.db.Model(model).WherePK().Delete()
+	_, err := wrapper.db.ModelContext(wrapper.ctx, model).WherePK().Delete()
 	if err == pg.ErrNoRows {
 		return nil
 	}
 	return err
 }
+
+// Query starts a query on the model for lookups which are not by primary key.
+func (wrapper *CrudRepositoryWrapper) Query(model interface{}) *orm.Query {
+	return wrapper.db.ModelContext(wrapper.ctx, model)
+}
+
+// Lock takes a transaction level advisory lock on the key, it is held until the unit of work the context
+// carries ends and concurrent units of work locking the same key wait for it.
+func (wrapper *CrudRepositoryWrapper) Lock(key string) error {
+	_, err := wrapper.db.ExecContext(wrapper.ctx, "SELECT pg_advisory_xact_lock(hashtext(?))", key)
+	return err
+}
+
+// RunInTransaction runs fn in a database transaction, every write made through
+// the wrapper given to fn is committed or rolled back together.
+func (wrapper *CrudRepositoryWrapper) RunInTransaction(fn func(tx *CrudRepositoryWrapper) error) error {
+	return wrapper.db.RunInTransaction(func(tx *pg.Tx) error {
+		return fn(&CrudRepositoryWrapper{db: tx, ctx: wrapper.ctx})
+	})
+}
@@ -0,0 +1,46 @@
+package pg
+
+import (
+	"github.com/go-pg/pg/v10"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	poolHitsDesc = prometheus.NewDesc("mobilebanking_db_pool_hits_total",
+		"Times a free connection was found in the pool.", nil, nil)
+	poolMissesDesc = prometheus.NewDesc("mobilebanking_db_pool_misses_total",
+		"Times no free connection was found in the pool.", nil, nil)
+	poolTimeoutsDesc = prometheus.NewDesc("mobilebanking_db_pool_timeouts_total",
+		"Times waiting for a connection timed out.", nil, nil)
+	poolConnectionsDesc = prometheus.NewDesc("mobilebanking_db_pool_connections",
+		"Connections in the pool, by state.", []string{"state"}, nil)
+	poolStaleConnectionsDesc = prometheus.NewDesc("mobilebanking_db_pool_stale_connections_total",
+		"Stale connections removed from the pool.", nil, nil)
+)
+
+// PoolCollector reads the go-pg pool statistics on every scrape
+type PoolCollector struct {
+	db *pg.DB
+}
+
+func NewPoolCollector(db *pg.DB) *PoolCollector {
+	return &PoolCollector{db: db}
+}
+
+func (collector *PoolCollector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- poolHitsDesc
+	descs <- poolMissesDesc
+	descs <- poolTimeoutsDesc
+	descs <- poolConnectionsDesc
+	descs <- poolStaleConnectionsDesc
+}
+
+func (collector *PoolCollector) Collect(metrics chan<- prometheus.Metric) {
+	stats := collector.db.PoolStats()
+	metrics <- prometheus.MustNewConstMetric(poolHitsDesc, prometheus.CounterValue, float64(stats.Hits))
+	metrics <- prometheus.MustNewConstMetric(poolMissesDesc, prometheus.CounterValue, float64(stats.Misses))
+	metrics <- prometheus.MustNewConstMetric(poolTimeoutsDesc, prometheus.CounterValue, float64(stats.Timeouts))
+	metrics <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(stats.TotalConns), "total")
+	metrics <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(stats.IdleConns), "idle")
+	metrics <- prometheus.MustNewConstMetric(poolStaleConnectionsDesc, prometheus.CounterValue, float64(stats.StaleConns))
+}
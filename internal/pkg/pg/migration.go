@@ -0,0 +1,28 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// MigrationVersion is the schema version this binary is written against, it has to be bumped
+// together with every migration added to scripts/postgres/migration
+const MigrationVersion int64 = 17
+
+const migrationTable = "gopg_migrations"
+
+// CheckMigrationVersion fails when the database schema is not at the version the binary expects
+func CheckMigrationVersion(ctx context.Context, db *pg.DB) error {
+	var version int64
+	_, err := db.QueryOneContext(ctx, pg.Scan(&version),
+		"SELECT version FROM ? ORDER BY id DESC LIMIT 1", pg.Ident(migrationTable))
+	if err != nil && err != pg.ErrNoRows {
+		return err
+	}
+	if version != MigrationVersion {
+		return fmt.Errorf("schema is at version %d, expected %d", version, MigrationVersion)
+	}
+	return nil
+}
@@ -1,33 +1,56 @@
-package pg
-
-import (
-	"log"
-
-	"github.com/go-pg/pg/v10"
-	"go.uber.org/dig"
-)
-
-func Register(container *dig.Container) {
-
-	container.Provide(func() *pg.Options {
-		return &pg.Options{
-			Database: "mobile-banking-service",
-			User:     "postgres",
-			Password: "postgres",
-		}
-	})
-
-	container.Provide(func(opts *pg.Options) *pg.DB {
-		return pg.Connect(opts)
-	})
-}
-
-func Invoke(container *dig.Container) {
-	err := container.Invoke(func(db *pg.DB) {
-		log.Println("invoke db...")
-		pgDb = db
-	})
-	if err != nil {
-		log.Fatal(err)
-	}
-}
+package pg
+
+import (
+	"context"
+	"log"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/health"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+	"go.uber.org/dig"
+)
+
+func Register(container *dig.Container) {
+
+	container.Provide(func(cfg *config.Config) *pg.Options {
+		return &pg.Options{
+			Addr:     cfg.Database.Addr,
+			Database: cfg.Database.Name,
+			User:     cfg.Database.User,
+			Password: cfg.Database.Password,
+			PoolSize: cfg.Database.PoolSize,
+		}
+	})
+
+	container.Provide(func(opts *pg.Options) *pg.DB {
+		db := pg.Connect(opts)
+		db.AddQueryHook(TracingHook{Database: opts.Database})
+		return db
+	})
+
+	container.Provide(func(db *pg.DB) *UnitOfWork {
+		return NewUnitOfWork(db)
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(db *pg.DB, serverLifecycle *lifecycle.Lifecycle, healthRegistry *health.Registry,
+		appMetrics *metrics.Metrics) error {
+		log.Println("invoke db...")
+		pgDb = db
+		healthRegistry.Register("postgres", db.Ping)
+		healthRegistry.Register("migrations", func(ctx context.Context) error {
+			return CheckMigrationVersion(ctx, db)
+		})
+		// registered after the modules so the pool is closed once their workers are stopped
+		serverLifecycle.OnStop("database", func(ctx context.Context) error {
+			return db.Close()
+		})
+		return appMetrics.Register(NewPoolCollector(db))
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,74 @@
+package pg
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/tunaiku/mobilebanking/internal/pkg/tracing"
+	"go.opentelemetry.io/otel/api/kv"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+const (
+	queryTracerName = "github.com/tunaiku/mobilebanking/pg"
+	maxStatementLen = 2000
+)
+
+type sqlOperation interface {
+	Operation() string
+}
+
+// TracingHook starts a client span per query when the caller is traced, the statement is recorded
+// unformatted so the bound values, like account numbers or credentials, never reach the exporter
+type TracingHook struct {
+	Database string
+}
+
+var _ pg.QueryHook = TracingHook{}
+
+func (hook TracingHook) BeforeQuery(ctx context.Context, event *pg.QueryEvent) (context.Context, error) {
+	if !trace.SpanFromContext(ctx).IsRecording() {
+		return ctx, nil
+	}
+	ctx, _ = tracing.Tracer(queryTracerName).Start(ctx, "pg", trace.WithSpanKind(trace.SpanKindClient))
+	return ctx, nil
+}
+
+func (hook TracingHook) AfterQuery(ctx context.Context, event *pg.QueryEvent) error {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return nil
+	}
+
+	statement, err := event.UnformattedQuery()
+	if err != nil {
+		tracing.End(ctx, span, err)
+		return nil
+	}
+	if len(statement) > maxStatementLen {
+		statement = statement[:maxStatementLen]
+	}
+	span.SetName("pg " + operation(event, string(statement)))
+	span.SetAttributes(
+		kv.String("db.system", "postgresql"),
+		kv.String("db.name", hook.Database),
+		kv.String("db.statement", string(statement)),
+	)
+	if event.Result != nil {
+		span.SetAttributes(kv.Int("db.rows_affected", event.Result.RowsAffected()))
+	}
+	tracing.End(ctx, span, event.Err)
+	return nil
+}
+
+// operation names the span after the SQL verb, the ORM queries know theirs
+func operation(event *pg.QueryEvent, statement string) string {
+	if query, ok := event.Query.(sqlOperation); ok {
+		return query.Operation()
+	}
+	if fields := strings.Fields(statement); len(fields) > 0 {
+		return strings.ToUpper(fields[0])
+	}
+	return "query"
+}
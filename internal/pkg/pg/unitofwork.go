@@ -0,0 +1,73 @@
+package pg
+
+import (
+	"context"
+	"log"
+
+	"github.com/go-pg/pg/v10"
+)
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+	// maxSerializationRetries is how many times a unit of work is run again after losing a serialization conflict
+	maxSerializationRetries = 3
+)
+
+type txKey struct{}
+
+// UnitOfWork runs a function in a database transaction carried by the context given to it, the
+// repositories reading their wrapper with FromContext join it so all their writes commit together.
+type UnitOfWork struct {
+	db *pg.DB
+}
+
+func NewUnitOfWork(db *pg.DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Do commits when fn succeeds and rolls back when it fails or panics. fn is run again on a serialization
+// failure so it must not have side effects outside the database, a Do nested in fn joins the outer unit.
+func (uow *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*pg.Tx); ok {
+		return fn(ctx)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := uow.run(ctx, fn)
+		if !isSerializationFailure(err) || attempt >= maxSerializationRetries {
+			return err
+		}
+		log.Println("unit of work lost a serialization conflict, retrying:", err)
+	}
+}
+
+func (uow *UnitOfWork) run(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := uow.db.BeginContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			log.Println("unit of work rollback failed:", rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func isSerializationFailure(err error) bool {
+	pgErr, ok := err.(pg.Error)
+	if !ok {
+		return false
+	}
+	code := pgErr.Field('C')
+	return code == serializationFailure || code == deadlockDetected
+}
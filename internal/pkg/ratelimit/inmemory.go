@@ -0,0 +1,41 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// InMemoryStore keeps the buckets of a single instance, every instance limits on its own
+type InMemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]Bucket
+}
+
+func NewInMemoryStore() *InMemoryStore {
+	return &InMemoryStore{buckets: make(map[string]Bucket)}
+}
+
+func (store *InMemoryStore) Take(ctx context.Context, key string, limit Limit,
+	now time.Time) (Decision, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	bucket, ok := store.buckets[key]
+	if !ok {
+		bucket = NewBucket(limit, now)
+	}
+	decision := bucket.Take(limit, now)
+	store.buckets[key] = bucket
+	return decision, nil
+}
+
+func (store *InMemoryStore) Sweep(ctx context.Context, now time.Time) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for key, bucket := range store.buckets {
+		if !bucket.FullAt.After(now) {
+			delete(store.buckets, key)
+		}
+	}
+	return nil
+}
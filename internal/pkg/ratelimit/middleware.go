@@ -0,0 +1,140 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/jwtauth"
+	"github.com/go-chi/render"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const (
+	GroupAuth        = "auth"
+	GroupTransaction = "transaction"
+	// GroupOtp limits the routes which may send an OTP, whatever group they belong to
+	GroupOtp = "otp"
+
+	retryAfterHeader = "Retry-After"
+	remainingHeader  = "X-RateLimit-Remaining"
+)
+
+type rateLimitedResponse struct {
+	Message string `json:"message"`
+}
+
+// LimitedError reports a request denied by Take, RetryAfter is the wait until the next token
+type LimitedError struct {
+	Group      string
+	RetryAfter time.Duration
+}
+
+func (err *LimitedError) Error() string {
+	return fmt.Sprintf("too many %s requests, retry after %s", err.Group, err.RetryAfter)
+}
+
+// Limiter hands out the middleware limiting every route group, a nil store disables the limits
+type Limiter struct {
+	store             Store
+	limits            map[string]Limit
+	trustForwardedFor bool
+	now               func() time.Time
+}
+
+func NewLimiter(store Store, cfg config.RateLimitConfig) *Limiter {
+	limits := make(map[string]Limit, len(cfg.Groups))
+	for name, group := range cfg.Groups {
+		limits[name] = NewLimit(group)
+	}
+	return &Limiter{store: store, limits: limits, trustForwardedFor: cfg.TrustForwardedFor, now: time.Now}
+}
+
+// Limit takes a token from the bucket of the caller in the group, keyed by the JWT subject when the
+// request was authenticated before and by the client IP otherwise. A denied request is answered with
+// 429 and Retry-After, a failing store lets the request through rather than locking every user out
+func (limiter *Limiter) Limit(group string) func(http.Handler) http.Handler {
+	_, limited := limiter.limits[group]
+	return func(next http.Handler) http.Handler {
+		if limiter.store == nil || !limited {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			decision, ok := limiter.take(r.Context(), group, limiter.key(r))
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set(remainingHeader, strconv.Itoa(decision.Remaining))
+			if !decision.Allowed {
+				RenderLimited(w, r, decision.RetryAfter)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Take takes a token from the bucket of the user in the group, for the routes which only reach the limited
+// resource on some of their paths. It shares the buckets of Limit and returns a *LimitedError when denied
+func (limiter *Limiter) Take(ctx context.Context, group string, userID string) error {
+	decision, ok := limiter.take(ctx, group, userKey(userID))
+	if ok && !decision.Allowed {
+		return &LimitedError{Group: group, RetryAfter: decision.RetryAfter}
+	}
+	return nil
+}
+
+// take reports false when the group is not limited or the store failed, the request is then let through
+func (limiter *Limiter) take(ctx context.Context, group string, key string) (Decision, bool) {
+	limit, limited := limiter.limits[group]
+	if limiter.store == nil || !limited {
+		return Decision{}, false
+	}
+	decision, err := limiter.store.Take(ctx, group+":"+key, limit, limiter.now().UTC())
+	if err != nil {
+		logger.FromContext(ctx).Warn("rate limit unavailable", zap.String("group", group), zap.Error(err))
+		return Decision{}, false
+	}
+	return decision, true
+}
+
+// RenderLimited answers a denied request with 429 and the seconds to wait in Retry-After
+func RenderLimited(w http.ResponseWriter, r *http.Request, retryAfter time.Duration) {
+	w.Header().Set(retryAfterHeader, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	render.Status(r, http.StatusTooManyRequests)
+	render.JSON(w, r, &rateLimitedResponse{Message: "too many requests, retry later"})
+}
+
+func (limiter *Limiter) key(r *http.Request) string {
+	if _, claims, err := jwtauth.FromContext(r.Context()); err == nil {
+		if subject, ok := claims["sub"].(string); ok && subject != "" {
+			return userKey(subject)
+		}
+	}
+	return "ip:" + ClientIP(r, limiter.trustForwardedFor)
+}
+
+func userKey(userID string) string {
+	return "user:" + userID
+}
+
+// ClientIP is the address of the caller, the first X-Forwarded-For address is only taken when the proxy
+// in front of the service is trusted to set it since any client can send the header
+func ClientIP(r *http.Request, trustForwardedFor bool) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); trustForwardedFor && forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,74 @@
+package ratelimit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+)
+
+func newRouter(store ratelimit.Store) http.Handler {
+	limiter := ratelimit.NewLimiter(store, config.RateLimitConfig{
+		Groups: map[string]config.RateLimitGroup{ratelimit.GroupAuth: {Requests: 1, Period: time.Hour}},
+	})
+	router := chi.NewRouter()
+	router.With(limiter.Limit(ratelimit.GroupAuth)).Post("/auth/authenticate", func(w http.ResponseWriter, r *http.Request) {})
+	router.With(limiter.Limit(ratelimit.GroupTransaction)).Post("/transaction", func(w http.ResponseWriter, r *http.Request) {})
+	return router
+}
+
+func post(router http.Handler, path string, remoteAddr string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	req.RemoteAddr = remoteAddr
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestLimit_Should_RespondTooManyRequestsWithRetryAfter_When_TheClientSpentItsBucket(t *testing.T) {
+	router := newRouter(ratelimit.NewInMemoryStore())
+	if resp := post(router, "/auth/authenticate", "10.0.0.1:5000"); resp.Code != http.StatusOK {
+		t.Fatalf("first request answered %d", resp.Code)
+	}
+
+	resp := post(router, "/auth/authenticate", "10.0.0.1:5001")
+	if resp.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 but got %d", resp.Code)
+	}
+	if retryAfter := resp.Header().Get("Retry-After"); retryAfter != "3600" {
+		t.Fatalf("expected to retry after 3600s but got `%s`", retryAfter)
+	}
+	if resp := post(router, "/auth/authenticate", "10.0.0.2:5000"); resp.Code != http.StatusOK {
+		t.Fatalf("another client was limited with %d", resp.Code)
+	}
+}
+
+func TestLimit_Should_LetEveryRequestThrough_When_TheGroupHasNoLimitOrTheLimiterNoStore(t *testing.T) {
+	limited := newRouter(ratelimit.NewInMemoryStore())
+	disabled := newRouter(nil)
+	for i := 0; i < 3; i++ {
+		if resp := post(limited, "/transaction", "10.0.0.1:5000"); resp.Code != http.StatusOK {
+			t.Fatalf("unconfigured group answered %d", resp.Code)
+		}
+		if resp := post(disabled, "/auth/authenticate", "10.0.0.1:5000"); resp.Code != http.StatusOK {
+			t.Fatalf("disabled limiter answered %d", resp.Code)
+		}
+	}
+}
+
+func TestClientIP_Should_IgnoreXForwardedFor_When_TheProxyIsNotTrusted(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/transaction", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.9")
+
+	if ip := ratelimit.ClientIP(req, false); ip != "10.0.0.1" {
+		t.Fatalf("expected the remote address but got %s", ip)
+	}
+	if ip := ratelimit.ClientIP(req, true); ip != "203.0.113.7" {
+		t.Fatalf("expected the first forwarded address but got %s", ip)
+	}
+}
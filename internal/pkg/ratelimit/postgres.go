@@ -0,0 +1,46 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/pg"
+)
+
+// rateLimitBucket is a row of rate_limit_buckets
+type rateLimitBucket struct {
+	Key string `pg:",pk"`
+	Bucket
+}
+
+// PostgresStore shares the buckets between the instances, the row of a key is locked while a token is taken
+type PostgresStore struct {
+}
+
+func NewPostgresStore() *PostgresStore {
+	return &PostgresStore{}
+}
+
+func (store *PostgresStore) Take(ctx context.Context, key string, limit Limit,
+	now time.Time) (Decision, error) {
+	var decision Decision
+	err := pg.FromContext(ctx).RunInTransaction(func(tx *pg.CrudRepositoryWrapper) error {
+		fresh := &rateLimitBucket{Key: key, Bucket: NewBucket(limit, now)}
+		if _, err := tx.Query(fresh).OnConflict("DO NOTHING").Insert(); err != nil {
+			return err
+		}
+		row := &rateLimitBucket{Key: key}
+		if err := tx.Query(row).WherePK().For("UPDATE").Select(); err != nil {
+			return err
+		}
+		decision = row.Take(limit, now)
+		_, err := tx.Query(row).WherePK().Column("tokens", "updated_at", "full_at").Update()
+		return err
+	})
+	return decision, err
+}
+
+func (store *PostgresStore) Sweep(ctx context.Context, now time.Time) error {
+	_, err := pg.FromContext(ctx).Query((*rateLimitBucket)(nil)).Where("full_at <= ?", now).Delete()
+	return err
+}
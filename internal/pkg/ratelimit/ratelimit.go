@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/scheduler"
+)
+
+// Limit refills a bucket of Burst tokens at Requests per Period, every request takes one token
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+func NewLimit(group config.RateLimitGroup) Limit {
+	limit := Limit{Requests: group.Requests, Period: group.Period, Burst: group.Burst}
+	if limit.Burst == 0 {
+		limit.Burst = limit.Requests
+	}
+	return limit
+}
+
+// rate is the number of tokens refilled per second
+func (limit Limit) rate() float64 {
+	return float64(limit.Requests) / limit.Period.Seconds()
+}
+
+// Bucket is the state kept per key, a bucket missing from the store is a full one
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+	// FullAt is when the bucket is full again, the store may forget it from then on
+	FullAt time.Time
+}
+
+func NewBucket(limit Limit, now time.Time) Bucket {
+	return Bucket{Tokens: float64(limit.Burst), UpdatedAt: now, FullAt: now}
+}
+
+type Decision struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is the wait until the next token when the request is denied
+	RetryAfter time.Duration
+}
+
+// Take refills the bucket for the time elapsed since its last update and takes a token if one is left
+func (bucket *Bucket) Take(limit Limit, now time.Time) Decision {
+	rate := limit.rate()
+	if elapsed := now.Sub(bucket.UpdatedAt); elapsed > 0 {
+		bucket.Tokens = math.Min(float64(limit.Burst), bucket.Tokens+elapsed.Seconds()*rate)
+		bucket.UpdatedAt = now
+	}
+
+	decision := Decision{}
+	if bucket.Tokens >= 1 {
+		bucket.Tokens--
+		decision.Allowed = true
+	} else {
+		decision.RetryAfter = seconds((1 - bucket.Tokens) / rate)
+	}
+	decision.Remaining = int(bucket.Tokens)
+	bucket.FullAt = bucket.UpdatedAt.Add(seconds((float64(limit.Burst) - bucket.Tokens) / rate))
+	return decision
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
+
+// Store keeps the buckets by key, Take has to refill and take from a bucket atomically
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Decision, error)
+	// Sweep forgets the buckets which are full again
+	Sweep(ctx context.Context, now time.Time) error
+}
+
+// SweepScheduler keeps the store from growing with the keys seen once
+type SweepScheduler struct {
+	*scheduler.Scheduler
+}
+
+func NewSweepScheduler(store Store, interval time.Duration) *SweepScheduler {
+	return &SweepScheduler{scheduler.New("rate limit sweep", interval, func(now time.Time, ctx context.Context) error {
+		return store.Sweep(ctx, now)
+	})}
+}
@@ -0,0 +1,60 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+)
+
+var (
+	limit   = ratelimit.Limit{Requests: 6, Period: time.Minute, Burst: 2}
+	started = time.Date(2020, 8, 1, 10, 0, 0, 0, time.UTC)
+)
+
+func TestTake_Should_DenyWithTheWaitForTheNextToken_When_TheBurstIsSpent(t *testing.T) {
+	bucket := ratelimit.NewBucket(limit, started)
+	for i := 0; i < limit.Burst; i++ {
+		if decision := bucket.Take(limit, started); !decision.Allowed {
+			t.Fatalf("request %d of the burst was denied", i+1)
+		}
+	}
+
+	decision := bucket.Take(limit, started)
+	if decision.Allowed || decision.RetryAfter != 10*time.Second {
+		t.Fatalf("expected a denial with a 10s wait, got %+v", decision)
+	}
+}
+
+func TestTake_Should_RefillAtTheLimitRate_When_TimePasses(t *testing.T) {
+	bucket := ratelimit.NewBucket(limit, started)
+	bucket.Take(limit, started)
+	bucket.Take(limit, started)
+
+	if decision := bucket.Take(limit, started.Add(10*time.Second)); !decision.Allowed {
+		t.Fatal("expected one token refilled after 10s")
+	}
+	if decision := bucket.Take(limit, started.Add(time.Hour)); !decision.Allowed || decision.Remaining != limit.Burst-1 {
+		t.Fatalf("expected the refill to stop at the burst, got %+v", decision)
+	}
+}
+
+func TestInMemoryStore_Should_ForgetTheBucket_When_ItIsFullAgain(t *testing.T) {
+	store := ratelimit.NewInMemoryStore()
+	ctx := context.Background()
+	store.Take(ctx, "ip:10.0.0.1", limit, started)
+	store.Take(ctx, "ip:10.0.0.1", limit, started)
+
+	if err := store.Sweep(ctx, started.Add(5*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if decision, _ := store.Take(ctx, "ip:10.0.0.1", limit, started.Add(5*time.Second)); decision.Allowed {
+		t.Fatal("the bucket was swept before it was full again")
+	}
+
+	store.Sweep(ctx, started.Add(time.Minute))
+	if decision, _ := store.Take(ctx, "ip:10.0.0.1", limit, started.Add(time.Minute)); decision.Remaining != limit.Burst-1 {
+		t.Fatalf("expected a fresh bucket after the sweep, got %+v", decision)
+	}
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"log"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+	"go.uber.org/dig"
+)
+
+// SweepInterval is how often the buckets which are full again are forgotten
+const SweepInterval = time.Minute
+
+func Register(container *dig.Container) {
+	container.Provide(func(cfg *config.Config) Store {
+		switch cfg.RateLimit.Backend {
+		case config.RateLimitBackendMemory:
+			return NewInMemoryStore()
+		case config.RateLimitBackendPostgres:
+			return NewPostgresStore()
+		}
+		return nil
+	})
+
+	container.Provide(func(store Store, cfg *config.Config) *Limiter {
+		return NewLimiter(store, cfg.RateLimit)
+	})
+
+	container.Provide(func(store Store) *SweepScheduler {
+		return NewSweepScheduler(store, SweepInterval)
+	})
+}
+
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(store Store, sweeper *SweepScheduler, serverLifecycle *lifecycle.Lifecycle) {
+		if store == nil {
+			log.Println("rate limiting disabled ...")
+			return
+		}
+		sweeper.Start()
+		serverLifecycle.OnStop("rate limit sweep scheduler", sweeper.Shutdown)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,92 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is called on every tick with the tick time in UTC, ctx is cancelled when the scheduler gives up
+// waiting for it on shutdown.
+type Job func(now time.Time, ctx context.Context) error
+
+// Scheduler runs a job periodically in its own goroutine.
+type Scheduler struct {
+	name     string
+	interval time.Duration
+	job      Job
+	once     sync.Once
+	stopOnce sync.Once
+	stop     chan struct{}
+	done     chan struct{}
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+func New(name string, interval time.Duration, job Job) *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		name:     name,
+		interval: interval,
+		job:      job,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start runs the scheduler in its own goroutine, calling it more than once has no effect.
+func (scheduler *Scheduler) Start() {
+	scheduler.once.Do(func() {
+		log.Println("starting", scheduler.name, "scheduler ...")
+		go scheduler.run()
+	})
+}
+
+// Stop signals the scheduler to exit and waits for the running job to finish, it is safe to call it twice.
+func (scheduler *Scheduler) Stop() {
+	scheduler.once.Do(func() {
+		close(scheduler.done)
+	})
+	scheduler.stopOnce.Do(func() {
+		close(scheduler.stop)
+	})
+	<-scheduler.done
+	scheduler.cancel()
+}
+
+// Shutdown stops the scheduler and lets the running job finish, once the context is done the job is
+// cancelled and Shutdown waits for it to return.
+func (scheduler *Scheduler) Shutdown(ctx context.Context) error {
+	stopped := make(chan struct{})
+	go func() {
+		scheduler.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		scheduler.cancel()
+		<-stopped
+		return ctx.Err()
+	}
+}
+
+func (scheduler *Scheduler) run() {
+	defer close(scheduler.done)
+	ticker := time.NewTicker(scheduler.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-scheduler.stop:
+			return
+		case now := <-ticker.C:
+			if err := scheduler.job(now.UTC(), scheduler.ctx); err != nil {
+				log.Println(scheduler.name, "scheduler failed:", err)
+			}
+		}
+	}
+}
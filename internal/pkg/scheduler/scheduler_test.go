@@ -0,0 +1,63 @@
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/scheduler"
+)
+
+func TestShutdown_Should_CancelTheRunningJobAndWaitForIt_When_TheDeadlinePasses(t *testing.T) {
+	started := make(chan struct{})
+	returned := make(chan struct{})
+	jobScheduler := scheduler.New("test", time.Millisecond, func(now time.Time, ctx context.Context) error {
+		select {
+		case <-started:
+			return nil
+		default:
+			close(started)
+		}
+		<-ctx.Done()
+		close(returned)
+		return ctx.Err()
+	})
+	jobScheduler.Start()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := jobScheduler.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("err should be `context.DeadlineExceeded` but was %v", err)
+	}
+	select {
+	case <-returned:
+	default:
+		t.Fatal("shutdown should wait for the cancelled job to return")
+	}
+}
+
+func TestShutdown_Should_LetTheRunningJobFinish_When_ItEndsBeforeTheDeadline(t *testing.T) {
+	started := make(chan struct{})
+	var cancelled bool
+	jobScheduler := scheduler.New("test", time.Millisecond, func(now time.Time, ctx context.Context) error {
+		select {
+		case <-started:
+			return nil
+		default:
+			close(started)
+		}
+		time.Sleep(10 * time.Millisecond)
+		cancelled = ctx.Err() != nil
+		return nil
+	})
+	jobScheduler.Start()
+	<-started
+
+	if err := jobScheduler.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if cancelled {
+		t.Fatal("the job should not be cancelled before the deadline")
+	}
+}
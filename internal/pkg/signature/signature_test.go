@@ -0,0 +1,35 @@
+package signature_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/signature"
+)
+
+func TestVerify_Should_ReturnTrue_When_TheSignatureMatchesAndIsRecent(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"transaction_id":"a3289ce9","status":"success"}`)
+	sig := signature.Sign("secret", now.Unix(), body)
+	if !signature.Verify("secret", now.Unix(), body, sig, 5*time.Minute, now) {
+		t.Fatal("signature should be valid")
+	}
+}
+
+func TestVerify_Should_ReturnFalse_When_TheBodyWasTamperedWith(t *testing.T) {
+	now := time.Now()
+	sig := signature.Sign("secret", now.Unix(), []byte(`{"status":"failed"}`))
+	if signature.Verify("secret", now.Unix(), []byte(`{"status":"success"}`), sig, 5*time.Minute, now) {
+		t.Fatal("signature should be invalid")
+	}
+}
+
+func TestVerify_Should_ReturnFalse_When_TheTimestampIsOutsideTheTolerance(t *testing.T) {
+	now := time.Now()
+	signedAt := now.Add(-10 * time.Minute).Unix()
+	body := []byte(`{"status":"success"}`)
+	sig := signature.Sign("secret", signedAt, body)
+	if signature.Verify("secret", signedAt, body, sig, 5*time.Minute, now) {
+		t.Fatal("signature should be expired")
+	}
+}
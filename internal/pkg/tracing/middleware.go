@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/tunaiku/mobilebanking/internal/pkg/logger"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	serverTracerName = "github.com/tunaiku/mobilebanking/http"
+	unmatchedRoute   = "unmatched"
+)
+
+// Middleware starts a server span per request, continuing the trace of the W3C traceparent header when
+// sent, and names it after the chi route pattern once served. The target is left out since paths and
+// query strings may carry account numbers. It has to be installed on the root router after
+// logger.RequestID so the request logger gets the trace id
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := propagation.ExtractHTTP(r.Context(), global.Propagators(), r.Header)
+		ctx, span := Tracer(serverTracerName).Start(ctx, "HTTP "+r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(standard.NetAttributesFromHTTPRequest("tcp", r)...),
+			trace.WithAttributes(standard.HTTPMethodKey.String(r.Method)),
+		)
+		defer span.End()
+		if spanContext := span.SpanContext(); spanContext.IsValid() {
+			ctx = logger.WithContext(ctx, logger.FromContext(ctx).With(zap.String("trace_id", spanContext.TraceID.String())))
+		}
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		next.ServeHTTP(ww, r.WithContext(ctx))
+
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+		route := unmatchedRoute
+		if routeContext := chi.RouteContext(r.Context()); routeContext != nil && routeContext.RoutePattern() != "" {
+			route = routeContext.RoutePattern()
+		}
+		span.SetName(r.Method + " " + route)
+		span.SetAttributes(standard.HTTPRouteKey.String(route))
+		span.SetAttributes(standard.HTTPAttributesFromHTTPStatusCode(status)...)
+		span.SetStatus(standard.SpanStatusFromHTTPStatusCode(status))
+	})
+}
@@ -0,0 +1,38 @@
+package tracing
+
+import (
+	"log"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+	"go.uber.org/dig"
+)
+
+func Register(container *dig.Container) {
+	container.Provide(func(cfg *config.Config) (*Provider, error) {
+		return New(cfg.Tracing)
+	})
+}
+
+// Invoke installs the provider globally, it has to run before the modules so their adapters and the
+// query hook trace through it. Flushing the provider is left to the last stop hook, see OnStop
+func Invoke(container *dig.Container) {
+	err := container.Invoke(func(provider *Provider) {
+		log.Println("invoke tracing ...")
+		Install(provider)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// OnStop registers the provider shutdown, it has to be invoked after the other modules so the spans
+// ended while their workers stop are still exported
+func OnStop(container *dig.Container) {
+	err := container.Invoke(func(provider *Provider, serverLifecycle *lifecycle.Lifecycle) {
+		serverLifecycle.OnStop("tracing", provider.Shutdown)
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/trace/stdout"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"google.golang.org/grpc/codes"
+)
+
+// Provider is the SDK trace provider together with the exporter it feeds, Shutdown flushes the
+// spans still buffered before stopping the exporter
+type Provider struct {
+	*sdktrace.Provider
+	processor sdktrace.SpanProcessor
+	stop      func() error
+}
+
+// New builds the provider for the configured exporter, with the none exporter spans are not recorded
+// but their context is still created and propagated
+func New(cfg config.TracingConfig) (*Provider, error) {
+	serviceResource := resource.New(standard.ServiceNameKey.String(cfg.ServiceName))
+	provider := &Provider{stop: func() error { return nil }}
+
+	switch cfg.Exporter {
+	case config.TracingExporterNone:
+		sdkProvider, err := sdktrace.NewProvider(sdktrace.WithConfig(sdktrace.Config{
+			DefaultSampler: sdktrace.NeverSample(),
+			Resource:       serviceResource,
+		}))
+		if err != nil {
+			return nil, err
+		}
+		provider.Provider = sdkProvider
+		return provider, nil
+	case config.TracingExporterStdout:
+		exporter, err := stdout.NewExporter(stdout.Options{})
+		if err != nil {
+			return nil, err
+		}
+		provider.processor = sdktrace.NewSimpleSpanProcessor(exporter)
+	case config.TracingExporterOTLP:
+		exporter, err := otlp.NewExporter(otlp.WithInsecure(), otlp.WithAddress(cfg.Endpoint))
+		if err != nil {
+			return nil, err
+		}
+		processor, err := sdktrace.NewBatchSpanProcessor(exporter)
+		if err != nil {
+			return nil, err
+		}
+		provider.processor = processor
+		provider.stop = exporter.Stop
+	default:
+		return nil, fmt.Errorf("tracing: exporter %q is not supported", cfg.Exporter)
+	}
+
+	sdkProvider, err := sdktrace.NewProvider(sdktrace.WithResource(serviceResource))
+	if err != nil {
+		return nil, err
+	}
+	sdkProvider.RegisterSpanProcessor(provider.processor)
+	provider.Provider = sdkProvider
+	return provider, nil
+}
+
+// Shutdown exports the pending spans and stops the exporter, spans ended afterwards are dropped
+func (provider *Provider) Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		if provider.processor != nil {
+			provider.UnregisterSpanProcessor(provider.processor)
+		}
+		done <- provider.stop()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Install makes the provider global and propagates the trace context through the W3C headers
+func Install(provider trace.Provider) {
+	global.SetTraceProvider(provider)
+	traceContext := trace.TraceContext{}
+	global.SetPropagators(propagation.New(
+		propagation.WithExtractors(traceContext),
+		propagation.WithInjectors(traceContext),
+	))
+}
+
+// Tracer returns the named tracer of the global provider, a tracer taken before Install follows the
+// provider once it is installed
+func Tracer(name string) trace.Tracer {
+	return global.Tracer(name)
+}
+
+// End records the error, if any, on the span before ending it
+func End(ctx context.Context, span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(ctx, err, trace.WithErrorStatus(codes.Unknown))
+	}
+	span.End()
+}
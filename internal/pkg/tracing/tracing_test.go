@@ -0,0 +1,128 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/tracing"
+	export "go.opentelemetry.io/otel/sdk/export/trace"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	remoteTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceparent   = "00-" + remoteTraceID + "-00f067aa0ba902b7-01"
+)
+
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []*export.SpanData
+}
+
+func (recorder *spanRecorder) ExportSpan(ctx context.Context, span *export.SpanData) {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	recorder.spans = append(recorder.spans, span)
+}
+
+func (recorder *spanRecorder) named(name string) *export.SpanData {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	for _, span := range recorder.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func installRecorder(t *testing.T) *spanRecorder {
+	recorder := &spanRecorder{}
+	provider, err := sdktrace.NewProvider(sdktrace.WithSyncer(recorder))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracing.Install(provider)
+	return recorder
+}
+
+func TestMiddleware_Should_ContinueTheCallerTraceUnderTheRoutePattern_When_ATraceparentIsSent(t *testing.T) {
+	recorder := installRecorder(t)
+	router := chi.NewRouter()
+	router.Use(tracing.Middleware)
+	router.Get("/transactions/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/transactions/42", nil)
+	req.Header.Set("traceparent", traceparent)
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	span := recorder.named("GET /transactions/{id}")
+	if span == nil {
+		t.Fatalf("no span named after the route in %d spans", len(recorder.spans))
+	}
+	if span.SpanContext.TraceID.String() != remoteTraceID || !span.HasRemoteParent {
+		t.Fatalf("expected the span to continue trace %s, got %s", remoteTraceID, span.SpanContext.TraceID)
+	}
+}
+
+func TestTransport_Should_InjectTheTraceContextWithoutRecordingThePath_When_CallingOut(t *testing.T) {
+	recorder := installRecorder(t)
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("traceparent")
+	}))
+	defer server.Close()
+
+	ctx, parent := tracing.Tracer("test").Start(context.Background(), "parent")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/accounts/10001234", nil)
+	client := &http.Client{Transport: tracing.NewTransport("corebanking", nil)}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	parent.End()
+
+	if !strings.Contains(received, parent.SpanContext().TraceID.String()) {
+		t.Fatalf("expected the traceparent `%s` to carry trace %s", received, parent.SpanContext().TraceID)
+	}
+	span := recorder.named("corebanking GET")
+	if span == nil {
+		t.Fatal("no client span recorded")
+	}
+	for _, attribute := range span.Attributes {
+		if strings.Contains(attribute.Value.Emit(), "10001234") {
+			t.Fatalf("the account number leaked in %s", attribute.Key)
+		}
+	}
+}
+
+func TestNew_Should_PropagateWithoutRecording_When_TheExporterIsNone(t *testing.T) {
+	cfg := config.Default().Tracing
+	provider, err := tracing.New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Shutdown(context.Background())
+
+	_, span := provider.Tracer("test").Start(context.Background(), "operation")
+	if span.IsRecording() || !span.SpanContext().IsValid() {
+		t.Fatal("expected a valid span context which is not recorded")
+	}
+}
+
+func TestNew_Should_Fail_When_TheExporterIsUnknown(t *testing.T) {
+	cfg := config.Default().Tracing
+	cfg.Exporter = "zipkin"
+	if _, err := tracing.New(cfg); err == nil {
+		t.Fatal("expected an error")
+	}
+}
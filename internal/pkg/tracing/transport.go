@@ -0,0 +1,50 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/standard"
+	"go.opentelemetry.io/otel/api/trace"
+)
+
+const clientTracerName = "github.com/tunaiku/mobilebanking/http/client"
+
+// Transport starts a client span per outbound request and injects the W3C headers so the remote side
+// continues the trace. Only the method and the host are recorded since the adapter paths carry account
+// numbers, the span covers the exchange up to the response headers
+type Transport struct {
+	name string
+	base http.RoundTripper
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+// NewTransport traces the requests made through base, the spans are named after the remote service
+func NewTransport(name string, base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{name: name, base: base}
+}
+
+func (transport *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, span := Tracer(clientTracerName).Start(r.Context(), transport.name+" "+r.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(standard.HTTPMethodKey.String(r.Method), standard.NetPeerNameKey.String(r.URL.Hostname())),
+	)
+	// a round tripper must not modify the request it is given
+	r = r.Clone(ctx)
+	propagation.InjectHTTP(ctx, global.Propagators(), r.Header)
+
+	resp, err := transport.base.RoundTrip(r)
+	if err != nil {
+		End(ctx, span, err)
+		return nil, err
+	}
+	span.SetAttributes(standard.HTTPAttributesFromHTTPStatusCode(resp.StatusCode)...)
+	span.SetStatus(standard.SpanStatusFromHTTPStatusCode(resp.StatusCode))
+	span.End()
+	return resp, nil
+}
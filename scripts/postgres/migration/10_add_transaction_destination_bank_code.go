@@ -0,0 +1,22 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("adding destination_bank_code to transactions...")
+		_, err := db.Exec(`
+		alter table transactions add column if not exists destination_bank_code varchar;
+		update transactions set destination_bank_code = 'TUNAIKU' where destination_bank_code is null;
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping destination_bank_code from transactions...")
+		_, err := db.Exec(`alter table transactions drop column if exists destination_bank_code`)
+		return err
+	})
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("creating table settlement_callbacks...")
+		_, err := db.Exec(`
+			alter table transactions add column if not exists external_reference varchar;
+			create unique index if not exists transactions_external_reference_idx
+				on transactions(external_reference) where external_reference is not null;
+			alter table transaction_events add column if not exists payload text;
+			create table if not exists settlement_callbacks(
+				id varchar primary key,
+				transaction_id varchar not null,
+				payload text not null,
+				received_at timestamp not null
+			);
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping table settlement_callbacks...")
+		_, err := db.Exec(`
+			drop table if exists settlement_callbacks;
+			alter table transaction_events drop column if exists payload;
+			drop index if exists transactions_external_reference_idx;
+			alter table transactions drop column if exists external_reference;
+		`)
+		return err
+	})
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("creating table outbox_messages...")
+		_, err := db.Exec(`
+			create table if not exists outbox_messages(
+				id varchar primary key,
+				transaction_id varchar not null unique,
+				payload text not null,
+				attempts int not null default 0,
+				last_error text,
+				next_attempt_at timestamp not null,
+				processed_at timestamp,
+				created_at timestamp not null
+			);
+			create index if not exists outbox_messages_pending_idx
+				on outbox_messages(next_attempt_at) where processed_at is null;
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping table outbox_messages...")
+		_, err := db.Exec(`drop table if exists outbox_messages;`)
+		return err
+	})
+}
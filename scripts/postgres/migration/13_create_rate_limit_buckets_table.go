@@ -0,0 +1,27 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("creating table rate_limit_buckets...")
+		_, err := db.Exec(`
+			create table if not exists rate_limit_buckets(
+				key varchar primary key,
+				tokens double precision not null,
+				updated_at timestamp not null,
+				full_at timestamp not null
+			);
+			create index if not exists rate_limit_buckets_full_at_idx on rate_limit_buckets(full_at);
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping table rate_limit_buckets...")
+		_, err := db.Exec(`drop table if exists rate_limit_buckets;`)
+		return err
+	})
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("creating index settlement_callbacks_transaction_id_idx...")
+		_, err := db.Exec(`
+			create index if not exists settlement_callbacks_transaction_id_idx on settlement_callbacks(transaction_id);
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping index settlement_callbacks_transaction_id_idx...")
+		_, err := db.Exec(`drop index if exists settlement_callbacks_transaction_id_idx;`)
+		return err
+	})
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("adding required and completed authorizations to standing orders...")
+		_, err := db.Exec(`
+		alter table standing_orders add column if not exists required_authorizations integer[];
+		alter table standing_orders add column if not exists completed_authorizations integer[];
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping required and completed authorizations from standing orders...")
+		_, err := db.Exec(`
+		alter table standing_orders drop column if exists completed_authorizations;
+		alter table standing_orders drop column if exists required_authorizations;
+		`)
+		return err
+	})
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("adding lease owner to standing orders...")
+		_, err := db.Exec(`alter table standing_orders add column if not exists lease_owner text;`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping lease owner from standing orders...")
+		_, err := db.Exec(`alter table standing_orders drop column if exists lease_owner;`)
+		return err
+	})
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("seeding the transactions of john...")
+		_, err := db.Exec(`
+		UPDATE transactions SET user_id = 'fc55e3a8-c0fb-40c7-ab8a-9cda3fca40d4'
+		WHERE id IN (
+			'16e70cef-04ba-45eb-a509-5460ea4c5316',
+			'e3dfd59e-e735-4ffd-8816-7127f81542d4',
+			'34a58731-284a-4e98-aa42-05a032da2469',
+			'3b48dea5-ef3a-45b3-8205-82e606e4dc92'
+		);
+		INSERT INTO transactions
+		(
+			id,
+			user_id,
+			state,
+			transaction_code,
+			amount,
+			source_account,
+			destination_account,
+			created_at,
+			authorization_method
+		)
+		VALUES(
+			'a3289ce9-0c83-4d2f-854f-3a4668c70a71',
+			'fc55e3a8-c0fb-40c7-ab8a-9cda3fca40d4',
+			1,
+			'T001',
+			3000,
+			'10001',
+			'10002',
+			'2020-07-31 07:56:15.092',
+			2
+		)
+		ON CONFLICT (id) DO NOTHING;
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("removing the transactions of john...")
+		_, err := db.Exec(`
+		DELETE FROM transactions WHERE id = 'a3289ce9-0c83-4d2f-854f-3a4668c70a71';
+		UPDATE transactions SET user_id = '4c4d8623-89ee-4d30-a42b-cecef89c352a'
+		WHERE id IN (
+			'16e70cef-04ba-45eb-a509-5460ea4c5316',
+			'e3dfd59e-e735-4ffd-8816-7127f81542d4',
+			'34a58731-284a-4e98-aa42-05a032da2469',
+			'3b48dea5-ef3a-45b3-8205-82e606e4dc92'
+		);
+		`)
+		return err
+	})
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("creating table transaction_events...")
+		_, err := db.Exec(`
+			create table if not exists transaction_events(
+				id varchar primary key,
+				transaction_id varchar not null,
+				from_state numeric,
+				to_state numeric not null,
+				actor_user_id varchar,
+				authorization_method numeric,
+				failure_reason varchar,
+				client_ip varchar,
+				created_at timestamp not null
+			);
+			create index if not exists transaction_events_transaction_id_idx
+				on transaction_events(transaction_id, created_at);
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping table transaction_events...")
+		_, err := db.Exec(`DROP TABLE transaction_events`)
+		return err
+	})
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("adding execution_date and failure_reason to transactions...")
+		_, err := db.Exec(`
+			alter table transactions
+				add column if not exists execution_date timestamp,
+				add column if not exists failure_reason varchar;
+			create index if not exists transactions_scheduled_idx
+				on transactions(state, execution_date);
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping execution_date and failure_reason from transactions...")
+		_, err := db.Exec(`
+			drop index if exists transactions_scheduled_idx;
+			alter table transactions
+				drop column if exists execution_date,
+				drop column if exists failure_reason;
+		`)
+		return err
+	})
+}
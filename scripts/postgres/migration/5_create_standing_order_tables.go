@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("creating table standing_orders and standing_order_runs...")
+		_, err := db.Exec(`
+			create table if not exists standing_orders(
+				id varchar primary key,
+				user_id varchar not null,
+				state numeric not null,
+				authorization_method numeric not null,
+				transaction_code varchar not null,
+				amount numeric not null,
+				source_account varchar not null,
+				destination_account varchar not null,
+				frequency numeric not null,
+				start_date timestamp not null,
+				end_date timestamp,
+				next_occurrence integer not null default 0,
+				next_run_at timestamp,
+				created_at timestamp not null
+			);
+			create index if not exists standing_orders_due_idx
+				on standing_orders(state, next_run_at);
+			create table if not exists standing_order_runs(
+				id varchar primary key,
+				standing_order_id varchar not null references standing_orders(id),
+				occurrence integer not null default 0,
+				scheduled_at timestamp not null,
+				status numeric not null,
+				transaction_id varchar,
+				reason varchar,
+				created_at timestamp not null,
+				unique (standing_order_id, occurrence)
+			);
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping table standing_order_runs and standing_orders...")
+		_, err := db.Exec(`
+			DROP TABLE standing_order_runs;
+			DROP TABLE standing_orders;
+		`)
+		return err
+	})
+}
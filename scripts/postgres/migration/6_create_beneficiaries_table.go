@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("creating table beneficiaries...")
+		_, err := db.Exec(`
+			create table if not exists beneficiaries(
+				id varchar primary key,
+				user_id varchar not null,
+				account_number varchar not null,
+				nickname varchar not null,
+				bank_code varchar not null,
+				created_at timestamp not null,
+				unique (user_id, bank_code, account_number)
+			);
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping table beneficiaries...")
+		_, err := db.Exec(`DROP TABLE beneficiaries`)
+		return err
+	})
+}
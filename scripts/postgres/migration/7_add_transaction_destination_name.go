@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("adding destination_name to transactions...")
+		_, err := db.Exec(`alter table transactions add column if not exists destination_name varchar`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping destination_name from transactions...")
+		_, err := db.Exec(`alter table transactions drop column if exists destination_name`)
+		return err
+	})
+}
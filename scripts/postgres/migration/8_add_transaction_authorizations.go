@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("adding required and completed authorizations to transactions...")
+		_, err := db.Exec(`
+		alter table transactions add column if not exists required_authorizations integer[];
+		alter table transactions add column if not exists completed_authorizations integer[];
+		`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping required and completed authorizations from transactions...")
+		_, err := db.Exec(`
+		alter table transactions drop column if exists completed_authorizations;
+		alter table transactions drop column if exists required_authorizations;
+		`)
+		return err
+	})
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/go-pg/migrations/v8"
+)
+
+func init() {
+	migrations.MustRegisterTx(func(db migrations.DB) error {
+		fmt.Println("adding fee to transactions...")
+		_, err := db.Exec(`alter table transactions add column if not exists fee numeric not null default 0`)
+		return err
+	}, func(db migrations.DB) error {
+		fmt.Println("dropping fee from transactions...")
+		_, err := db.Exec(`alter table transactions drop column if exists fee`)
+		return err
+	})
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/go-pg/migrations/v8"
 	"github.com/go-pg/pg/v10"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
 	appPg "github.com/tunaiku/mobilebanking/internal/pkg/pg"
 	"go.uber.org/dig"
 )
@@ -28,11 +29,12 @@ var (
 )
 
 func init() {
+	config.Register(container)
 	appPg.Register(container)
 }
 
 func main() {
-	container.Invoke(func(db *pg.DB) {
+	err := container.Invoke(func(db *pg.DB) {
 		flag.Usage = usage
 		flag.Parse()
 
@@ -46,6 +48,9 @@ func main() {
 			fmt.Printf("version is %d\n", oldVersion)
 		}
 	})
+	if err != nil {
+		exitf(err.Error())
+	}
 }
 
 func usage() {
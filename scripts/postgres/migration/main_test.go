@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/go-pg/migrations/v8"
+	appPg "github.com/tunaiku/mobilebanking/internal/pkg/pg"
+)
+
+func TestMigrations_Should_MatchTheVersionExpectedByTheService_When_AMigrationIsAdded(t *testing.T) {
+	var latest int64
+	for _, migration := range migrations.RegisteredMigrations() {
+		if migration.Version > latest {
+			latest = migration.Version
+		}
+	}
+	if latest != appPg.MigrationVersion {
+		t.Fatalf("latest migration is %d but the service expects %d, bump pg.MigrationVersion", latest, appPg.MigrationVersion)
+	}
+}
@@ -0,0 +1,32 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+
+	httpexpect "github.com/gavv/httpexpect/v2"
+	"github.com/tunaiku/mobilebanking/test/e2e/setup"
+)
+
+func TestListBankEndpoint_Should_ReturnTheBankDirectory_When_TheUserIsAuthenticated(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		resp := e.GET("/banks").WithHeader("Authorization", johnAccessToken).Expect()
+		resp.Status(http.StatusOK)
+		resp.JSON().Path("$.banks[0].code").Equal("TUNAIKU")
+	})
+}
+
+func TestCreateTransactionEndpoint_Should_ReturnHttpStatusBadRequest_When_AnInterbankTransferUsesAnIntraBankCode(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		resp := e.POST("/transaction").WithHeader("Authorization", johnAccessToken).
+			WithJSON(map[string]interface{}{
+				"auth_method":           "pin",
+				"amount":                3000,
+				"transaction_code":      "T001",
+				"destination_account":   "0123456789",
+				"destination_bank_code": "BCA",
+			}).Expect()
+		resp.Status(http.StatusBadRequest)
+		resp.JSON().Object().ValueEqual("message", "transfers to other banks require transaction code T002")
+	})
+}
@@ -0,0 +1,37 @@
+package e2e_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	httpexpect "github.com/gavv/httpexpect/v2"
+	"github.com/tunaiku/mobilebanking/internal/app/clearing/alias"
+	"github.com/tunaiku/mobilebanking/internal/pkg/signature"
+	"github.com/tunaiku/mobilebanking/test/e2e/setup"
+)
+
+const unknownSettlementCallback = `{"callback_id":"5b0c3f3e-1f7a-4d0e-9a53-0c4f7d1f1a11",` +
+	`"transaction_id":"00000000-0000-0000-0000-000000000000","status":"success"}`
+
+func TestSettlementCallbackEndpoint_Should_ReturnHttpStatusUnauthorized_When_TheSignatureIsInvalid(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.POST("/callbacks/settlement").
+			WithHeader(alias.TimestampHeader, strconv.FormatInt(time.Now().Unix(), 10)).
+			WithHeader(alias.SignatureHeader, "invalid").
+			WithBytes([]byte(unknownSettlementCallback)).
+			Expect().Status(http.StatusUnauthorized)
+	})
+}
+
+func TestSettlementCallbackEndpoint_Should_ReturnHttpStatusNotFound_When_TheTransactionIsUnknown(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		timestamp := time.Now().Unix()
+		e.POST("/callbacks/settlement").
+			WithHeader(alias.TimestampHeader, strconv.FormatInt(timestamp, 10)).
+			WithHeader(alias.SignatureHeader, signature.Sign(setup.CallbackSecret(), timestamp, []byte(unknownSettlementCallback))).
+			WithBytes([]byte(unknownSettlementCallback)).
+			Expect().Status(http.StatusNotFound)
+	})
+}
@@ -0,0 +1,28 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+
+	httpexpect "github.com/gavv/httpexpect/v2"
+	"github.com/tunaiku/mobilebanking/test/e2e/setup"
+)
+
+func TestHealthEndpoint_Should_ReturnHttpStatusOk_When_TheServiceIsRunning(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.GET("/healthz").
+			Expect().Status(http.StatusOK).
+			JSON().Object().ValueEqual("status", "up")
+	})
+}
+
+func TestReadinessEndpoint_Should_ReportEveryComponent_When_TheDependenciesAreUp(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		components := e.GET("/readyz").
+			Expect().Status(http.StatusOK).
+			JSON().Object().ValueEqual("status", "up").
+			Value("components").Object()
+		components.Keys().ContainsOnly("postgres", "migrations", "savings", "otp")
+		components.Value("postgres").Object().ValueEqual("status", "up")
+	})
+}
@@ -0,0 +1,39 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+
+	httpexpect "github.com/gavv/httpexpect/v2"
+	"github.com/tunaiku/mobilebanking/test/e2e/setup"
+)
+
+func TestGetLimitsEndpoint_Should_ReturnTheLimitsOfThePrimaryAccountTier_When_NoAccountIsGiven(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		resp := e.GET("/limits").WithHeader("Authorization", johnAccessToken).Expect()
+		resp.Status(http.StatusOK)
+		resp.JSON().Object().ValueEqual("account_number", "10001").ValueEqual("tier", "savings")
+		resp.JSON().Path("$.limits").Array().Length().Equal(2)
+	})
+}
+
+func TestGetLimitsEndpoint_Should_ReturnHttpStatusNotFound_When_TheAccountIsNotLinkedToTheUser(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.GET("/limits").WithQuery("account_number", "10002").WithHeader("Authorization", johnAccessToken).
+			Expect().Status(http.StatusNotFound)
+	})
+}
+
+func TestCreateTransactionEndpoint_Should_ReturnHttpStatusBadRequest_When_TheAmountExceedsThePerTransactionLimit(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		resp := e.POST("/transaction").WithHeader("Authorization", johnAccessToken).
+			WithJSON(map[string]interface{}{
+				"auth_method":         "pin",
+				"amount":              30000000,
+				"transaction_code":    "T001",
+				"destination_account": "10002",
+			}).Expect()
+		resp.Status(http.StatusBadRequest)
+		resp.JSON().Object().ValueEqual("message", "amount exceeds the per transaction limit, the maximum is IDR 25000000")
+	})
+}
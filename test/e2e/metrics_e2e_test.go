@@ -0,0 +1,23 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+
+	httpexpect "github.com/gavv/httpexpect/v2"
+	"github.com/tunaiku/mobilebanking/test/e2e/setup"
+)
+
+func TestMetricsEndpoint_Should_ExposeTheRequestsAndLoginFailures_When_ALoginFails(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.POST("/auth/authenticate").WithJSON(map[string]interface{}{
+			"username": "john",
+			"password": "wrong",
+		}).Expect().Status(http.StatusBadRequest)
+
+		body := e.GET("/metrics").Expect().Status(http.StatusOK).Body()
+		body.Contains(`mobilebanking_http_requests_total{method="POST",route="/auth/authenticate",status="400"}`)
+		body.Contains("mobilebanking_login_failures_total")
+		body.Contains("mobilebanking_db_pool_connections")
+	})
+}
@@ -0,0 +1,45 @@
+package e2e_test
+
+import (
+	"net/http"
+	"testing"
+
+	httpexpect "github.com/gavv/httpexpect/v2"
+	"github.com/tunaiku/mobilebanking/test/e2e/setup"
+)
+
+func TestOpenAPIEndpoint_Should_DocumentTheAuthenticationAndTransactionRoutes(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		spec := e.GET("/openapi.json").Expect().Status(http.StatusOK).JSON().Object()
+		spec.ValueEqual("openapi", "3.0.3")
+		spec.Path("$.paths").Object().ContainsKey("/auth/authenticate").ContainsKey("/transaction").
+			ContainsKey("/transaction/{id}/verify").ContainsKey("/transaction/{id}")
+		spec.Path("$.components.schemas").Object().ContainsKey("AuthenticationRequest").
+			ContainsKey("CreateTransactionDto").ContainsKey("VerifyTransactionRequest").
+			ContainsKey("GetTransactionSuccess").ContainsKey("TransactionHandlerFailed")
+
+		e.GET("/docs").Expect().Status(http.StatusOK).ContentType("text/html")
+	})
+}
+
+func TestCreateTransactionEndpoint_Should_ReturnHttpStatusBadRequest_When_TheBodyDoesNotMatchTheSchema(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.POST("/transaction").WithHeader("Authorization", johnAccessToken).
+			WithJSON(map[string]interface{}{
+				"auth_method":         "pin",
+				"amount":              "3000",
+				"transaction_code":    "T001",
+				"destination_account": "10002",
+			}).Expect().Status(http.StatusBadRequest).
+			JSON().Object().Value("message").String().Contains("amount")
+	})
+}
+
+func TestAuthenticateEndpoint_Should_ReturnHttpStatusBadRequest_When_ThePasswordIsMissing(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.POST("/auth/authenticate").WithJSON(map[string]interface{}{
+			"username": "john",
+		}).Expect().Status(http.StatusBadRequest).
+			JSON().Object().Value("message").String().Contains("password")
+	})
+}
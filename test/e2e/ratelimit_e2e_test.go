@@ -0,0 +1,42 @@
+package e2e_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	httpexpect "github.com/gavv/httpexpect/v2"
+	"github.com/google/uuid"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+	"github.com/tunaiku/mobilebanking/test/e2e/setup"
+)
+
+func TestPostgresRateLimitStore_Should_ShareTheBucket_When_TwoInstancesTakeFromTheSameKey(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		ctx := context.Background()
+		limit := ratelimit.Limit{Requests: 2, Period: time.Hour, Burst: 2}
+		key := "e2e:" + uuid.New().String()
+		now := time.Now().UTC()
+		first, second := ratelimit.NewPostgresStore(), ratelimit.NewPostgresStore()
+
+		for _, store := range []*ratelimit.PostgresStore{first, second} {
+			decision, err := store.Take(ctx, key, limit, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !decision.Allowed {
+				t.Fatal("a request within the burst was denied")
+			}
+		}
+		decision, err := first.Take(ctx, key, limit, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if decision.Allowed || decision.RetryAfter != 30*time.Minute {
+			t.Fatalf("expected the shared bucket to be spent, got %+v", decision)
+		}
+		if err := first.Sweep(ctx, now.Add(time.Hour)); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
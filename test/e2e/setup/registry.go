@@ -3,41 +3,92 @@ package setup
 import (
 	"log"
 	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/gavv/httpexpect/v2"
 	"github.com/go-chi/chi"
 	"github.com/tunaiku/mobilebanking/internal/app/authentication"
+	"github.com/tunaiku/mobilebanking/internal/app/bank"
+	"github.com/tunaiku/mobilebanking/internal/app/beneficiary"
+	"github.com/tunaiku/mobilebanking/internal/app/clearing"
+	"github.com/tunaiku/mobilebanking/internal/app/limit"
 	"github.com/tunaiku/mobilebanking/internal/app/savings"
+	"github.com/tunaiku/mobilebanking/internal/app/standingorder"
 	"github.com/tunaiku/mobilebanking/internal/app/transaction"
 	"github.com/tunaiku/mobilebanking/internal/app/user"
+	"github.com/tunaiku/mobilebanking/internal/pkg/config"
+	"github.com/tunaiku/mobilebanking/internal/pkg/health"
+	"github.com/tunaiku/mobilebanking/internal/pkg/jwt"
+	"github.com/tunaiku/mobilebanking/internal/pkg/lifecycle"
+	"github.com/tunaiku/mobilebanking/internal/pkg/logger"
+	"github.com/tunaiku/mobilebanking/internal/pkg/metrics"
+	"github.com/tunaiku/mobilebanking/internal/pkg/openapi"
 	"github.com/tunaiku/mobilebanking/internal/pkg/pg"
+	"github.com/tunaiku/mobilebanking/internal/pkg/ratelimit"
+	"github.com/tunaiku/mobilebanking/internal/pkg/tracing"
 	"go.uber.org/dig"
+	"go.uber.org/zap"
 )
 
+// callbackSecretEnv names the variable holding the secret the settlement callbacks are signed with,
+// the tests set their own when the environment does not provide one
+const callbackSecretEnv = "CLEARING_CALLBACK_SECRET"
+
 var (
 	Container = dig.New()
 )
 
 func init() {
 	log.Println("register ...")
+	if _, ok := os.LookupEnv(callbackSecretEnv); !ok {
+		os.Setenv(callbackSecretEnv, "e2e-callback-secret")
+	}
+	config.Register(Container)
+	jwt.Register(Container)
+	logger.Register(Container)
+	lifecycle.Register(Container)
+	health.Register(Container)
+	metrics.Register(Container)
+	tracing.Register(Container)
+	ratelimit.Register(Container)
+	openapi.Register(Container)
 	transaction.Register(Container)
+	standingorder.Register(Container)
+	beneficiary.Register(Container)
+	limit.Register(Container)
+	bank.Register(Container)
+	clearing.Register(Container)
 	pg.Register(Container)
 	authentication.Register(Container)
 	savings.Register(Container)
 	user.Register(Container)
-	Container.Provide(func() chi.Router {
-		return chi.NewRouter()
+	Container.Provide(func(appMetrics *metrics.Metrics, appLogger *zap.Logger) chi.Router {
+		router := chi.NewRouter()
+		router.Use(logger.RequestID(appLogger), tracing.Middleware, logger.AccessLog, appMetrics.Middleware)
+		return router
 	})
 
 }
 
 func InvokeHttpTest(t *testing.T, testFunc func(expect *httpexpect.Expect)) {
+	logger.Invoke(Container)
+	tracing.Invoke(Container)
 	transaction.Invoke(Container)
+	standingorder.Invoke(Container)
+	beneficiary.Invoke(Container)
+	limit.Invoke(Container)
+	bank.Invoke(Container)
+	clearing.Invoke(Container)
 	authentication.Invoke(Container)
 	savings.Invoke(Container)
 	user.Invoke(Container)
+	ratelimit.Invoke(Container)
 	pg.Invoke(Container)
+	health.Invoke(Container)
+	metrics.Invoke(Container)
+	openapi.Invoke(Container)
+	tracing.OnStop(Container)
 	Container.Invoke(func(router chi.Router) {
 		server := httptest.NewServer(router)
 		defer server.Close()
@@ -45,3 +96,14 @@ func InvokeHttpTest(t *testing.T, testFunc func(expect *httpexpect.Expect)) {
 		testFunc(e)
 	})
 }
+
+// CallbackSecret is the configured secret the tests sign the settlement callbacks with
+func CallbackSecret() (secret string) {
+	err := Container.Invoke(func(cfg *config.Config) {
+		secret = cfg.Clearing.CallbackSecret
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	return secret
+}
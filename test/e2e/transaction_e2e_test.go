@@ -182,6 +182,89 @@ func Test_should_be_failed_when_destination_account_it_not_found(t *testing.T) {
 	})
 }
 
+func Test_should_be_failed_when_execution_date_is_in_the_past(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		endpoint := "/transaction"
+		httpMethod := "post"
+		httpExpect := e
+		desc := " should be failed with '400' as http status code and {\"message\":\"execution date must be in the future\"} when execution_date is in the past"
+		payload := map[string]interface{}{
+			"auth_method":         "pin",
+			"amount":              3000,
+			"transaction_code":    "T001",
+			"destination_account": "10002",
+			"execution_date":      "2020-07-31T07:56:15Z",
+		}
+		responseHTTPStatus := http.StatusBadRequest
+		responseBodyExpecter := func(resp *httpexpect.Response) {
+			resp.JSON().Object().ValueEqual("message", "execution date must be in the future")
+		}
+		runTestsCreateTransaction(t, endpoint, httpMethod, httpExpect, desc, payload, responseHTTPStatus, responseBodyExpecter)
+	})
+}
+
+func Test_should_be_failed_when_both_destination_account_and_beneficiary_id_are_given(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		endpoint := "/transaction"
+		httpMethod := "post"
+		httpExpect := e
+		desc := " should be failed with '400' as http status code when both destination_account and beneficiary_id are given"
+		payload := map[string]interface{}{
+			"auth_method":         "pin",
+			"amount":              3000,
+			"transaction_code":    "T001",
+			"destination_account": "10002",
+			"beneficiary_id":      "a3289ce9-0c83-4d2f-854f-3a4668c70a71",
+		}
+		responseHTTPStatus := http.StatusBadRequest
+		responseBodyExpecter := func(resp *httpexpect.Response) {
+			resp.JSON().Object().ValueEqual("message", "either destination_account or beneficiary_id must be given, not both")
+		}
+		runTestsCreateTransaction(t, endpoint, httpMethod, httpExpect, desc, payload, responseHTTPStatus, responseBodyExpecter)
+	})
+}
+
+func Test_should_be_failed_when_source_account_is_not_linked_to_the_user(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		endpoint := "/transaction"
+		httpMethod := "post"
+		httpExpect := e
+		desc := " should be failed with '400' as http status code when source_account is not linked to the user"
+		payload := map[string]interface{}{
+			"auth_method":         "pin",
+			"amount":              3000,
+			"transaction_code":    "T001",
+			"source_account":      "10002",
+			"destination_account": "10001",
+		}
+		responseHTTPStatus := http.StatusBadRequest
+		responseBodyExpecter := func(resp *httpexpect.Response) {
+			resp.JSON().Object().ValueEqual("message", "source account is not linked to the user")
+		}
+		runTestsCreateTransaction(t, endpoint, httpMethod, httpExpect, desc, payload, responseHTTPStatus, responseBodyExpecter)
+	})
+}
+
+func Test_should_be_failed_when_the_amount_requires_otp_but_the_user_not_configure_it(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		endpoint := "/transaction"
+		httpMethod := "post"
+		httpExpect := e
+		desc := " should be failed with '400' as http status code when the amount requires an OTP which the user did not configure"
+		payload := map[string]interface{}{
+			"auth_method":         "pin",
+			"amount":              5000000,
+			"transaction_code":    "T001",
+			"destination_account": "10002",
+		}
+		responseHTTPStatus := http.StatusBadRequest
+		responseBodyExpecter := func(resp *httpexpect.Response) {
+			resp.JSON().Object().ValueEqual("message", "authorization method not configured")
+		}
+		runTestsCreateTransaction(t, endpoint, httpMethod, httpExpect, desc, payload, responseHTTPStatus, responseBodyExpecter)
+	})
+}
+
 func runTestsVerifyTransaction(t *testing.T, endpoint string, httpMethod string, httpExpect *httpexpect.Expect, desc string,
 	pathVariables map[string]interface{}, payload map[string]interface{}, responseHTTPStatus int, responseBodyExpecter func(*httpexpect.Response)) {
 
@@ -244,7 +327,7 @@ func Test_transaction_should_be_failed_when_valid_credential_is_invalid(t *testi
 		httpExpect := e
 		desc := " transaction should be failed with `400-Bad Request` and `{\"message\":\"invalid credential\"}`  when valid credential is invalid "
 		pathVariables := map[string]interface{}{
-			"ID": "a3289ce9-0c83-4d2f-854f-3a4668c70a71",
+			"ID": "e3dfd59e-e735-4ffd-8816-7127f81542d4",
 		}
 		payload := map[string]interface{}{
 			"credential": "1234",
@@ -281,6 +364,50 @@ func Test_transaction_should_be_failed_when_there_is_no_transaction_with_belong_
 	})
 }
 
+func TestVerifyTransactionEndpoint_Should_ReturnHttpStatusNotFound_When_TheTransactionBelongsToAnotherUser(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.PUT("/transaction/16e70cef-04ba-45eb-a509-5460ea4c5316/verify").WithHeader("Authorization", janeAccessToken).
+			WithJSON(map[string]interface{}{"credential": "111111"}).
+			Expect().Status(http.StatusNotFound).
+			JSON().Object().ValueEqual("message", "transaction not found")
+	})
+}
+
+func TestGetTransactionEndpoint_Should_ReturnHttpStatusNotFound_When_TheTransactionBelongsToAnotherUser(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.GET("/transaction/34a58731-284a-4e98-aa42-05a032da2469").WithHeader("Authorization", janeAccessToken).
+			Expect().Status(http.StatusNotFound).
+			JSON().Object().ValueEqual("message", "transaction not found")
+	})
+}
+
+func TestGetTransactionEventsEndpoint_Should_ReturnHttpStatusNotFound_When_TheTransactionBelongsToAnotherUser(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.GET("/transaction/34a58731-284a-4e98-aa42-05a032da2469/events").WithHeader("Authorization", janeAccessToken).
+			Expect().Status(http.StatusNotFound).
+			JSON().Object().ValueEqual("message", "transaction not found")
+	})
+}
+
+func Test_transaction_events_should_be_failed_when_there_is_no_transaction_with_belong_to_path_variable_id(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		endpoint := "/transaction/{{.ID}}/events"
+		httpMethod := "get"
+		httpExpect := e
+		desc := " transaction events should be failed with `404-Not Found` and `{\"message\":\"transaction not found\"}`  when there is no transaction with belong to path variable id "
+		pathVariables := map[string]interface{}{
+			"ID": "1112",
+		}
+		responseHTTPStatus := http.StatusNotFound
+		responseBodyExpecter := func(resp *httpexpect.Response) {
+			resp.JSON().Object().ValueEqual("message", "transaction not found")
+		}
+
+		runTestsVerifyTransaction(t, endpoint, httpMethod, httpExpect, desc, pathVariables,
+			nil, responseHTTPStatus, responseBodyExpecter)
+	})
+}
+
 //func (tbl *transactionEndpointTestTable) runTests(t *testing.T) {
 //
 //	for _, tC := range tbl.testCases {
@@ -486,3 +613,50 @@ func Test_transaction_should_be_failed_when_there_is_no_transaction_with_belong_
 //			payload, responseHTTPStatus, responseBodyExpecter)
 //	})
 //}
+
+func TestQuoteTransactionEndpoint_Should_ReturnTheTotalIncludingTheFee_When_ThePayloadIsValid(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		resp := e.POST("/transaction/quote").WithHeader("Authorization", johnAccessToken).
+			WithJSON(map[string]interface{}{
+				"transaction_code": "T001",
+				"amount":           3000,
+			}).Expect()
+		resp.Status(http.StatusOK)
+		resp.JSON().Object().ValueEqual("fee", 0).ValueEqual("total", 3000).ValueEqual("currency", "IDR")
+	})
+}
+
+func TestQuoteTransactionEndpoint_Should_ReturnHttpStatusBadRequest_When_TheTransactionCodeIsNotFound(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		e.POST("/transaction/quote").WithHeader("Authorization", johnAccessToken).
+			WithJSON(map[string]interface{}{
+				"transaction_code": "T003",
+				"amount":           3000,
+			}).Expect().Status(http.StatusBadRequest)
+	})
+}
+
+func TestVerifyTransactionEndpoint_Should_PostThroughTheOutbox_When_TheTransactionIsAuthorized(t *testing.T) {
+	setup.InvokeHttpTest(t, func(e *httpexpect.Expect) {
+		transactionID := e.POST("/transaction").WithHeader("Authorization", johnAccessToken).
+			WithJSON(map[string]interface{}{
+				"auth_method":         "pin",
+				"amount":              3000,
+				"transaction_code":    "T001",
+				"destination_account": "10002",
+			}).Expect().Status(http.StatusCreated).
+			JSON().Object().Value("transaction_id").String().Raw()
+
+		e.PUT("/transaction/"+transactionID+"/verify").WithHeader("Authorization", johnAccessToken).
+			WithJSON(map[string]interface{}{"credential": "123456"}).
+			Expect().Status(http.StatusAccepted).
+			JSON().Object().ValueEqual("state", "Success")
+
+		events := e.GET("/transaction/"+transactionID+"/events").WithHeader("Authorization", johnAccessToken).
+			Expect().Status(http.StatusOK).
+			JSON().Path("$.events").Array()
+		events.Length().Equal(3)
+		events.Element(1).Object().ValueEqual("to_state", "Processing")
+		events.Element(2).Object().ValueEqual("to_state", "Success").ValueEqual("actor_user_id", "system")
+	})
+}